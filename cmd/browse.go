@@ -8,8 +8,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 
+	"github.com/Banh-Canh/jtui/internal/config"
 	"github.com/Banh-Canh/jtui/internal/ui"
 	"github.com/Banh-Canh/jtui/pkg/jellyfin"
 )
@@ -23,16 +23,16 @@ Browse your Jellyfin media library using an interactive TUI.
 Navigate with arrow keys or hjkl. Enter to open, Space to play/pause.
 Press / to search, d to download, w to toggle watched, q to quit.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		// Create and authenticate client
-		client, err := jellyfin.ConnectFromConfig(func(key string) string {
-			return viper.GetString(key)
-		})
+		runFirstRunSetup()
+
+		// Create client, restoring a saved session if there is one
+		client, err := jellyfin.PrepareFromConfig(config.ProfileGetter(config.ActiveProfile()))
 		if err != nil {
 			fmt.Printf("❌ Error connecting to Jellyfin: %v\n", err)
 			os.Exit(1)
 		}
 
-		// Start TUI with authenticated client
+		// Start TUI, which shows the login screen if needed
 		ui.MenuWithClient(client)
 	},
 }
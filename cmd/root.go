@@ -22,6 +22,8 @@ var (
 	versionFlag  bool
 	version      string
 	logLevelFlag string
+	profileFlag  string
+	firstRun     bool // true when the config file was created by this run
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -34,6 +36,7 @@ The configuration file is autogenerated on first run at *$HOME/.config/jtui/conf
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 		// Initialize configuration here
 		initConfig()
+		selectProfile()
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		defer func() {
@@ -50,6 +53,7 @@ The configuration file is autogenerated on first run at *$HOME/.config/jtui/conf
 		if versionFlag {
 			fmt.Printf("%s\n", version)
 		} else {
+			runFirstRunSetup()
 			ui.Menu()
 		}
 	},
@@ -64,7 +68,7 @@ func initConfig() {
 	}
 	configPath := filepath.Join(configDir, "config.yaml")
 	viper.SetConfigFile(configPath)
-	config.CreateDefaultConfigFile(configPath)
+	firstRun = config.CreateDefaultConfigFile(configPath)
 	if err := viper.ReadInConfig(); err != nil {
 		fmt.Printf("❌ Couldn't read config file: %v\n", err)
 		os.Exit(1)
@@ -92,6 +96,18 @@ func initConfig() {
 	utils.Logger.Info("Initialized configuration.")
 }
 
+// selectProfile activates the profile given with --profile, or the configured default
+func selectProfile() {
+	if err := config.SetActiveProfile(profileFlag); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if err := config.CheckDownloadsDirs(); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
 func Execute() {
 	err := RootCmd.Execute()
 	if err != nil {
@@ -102,4 +118,5 @@ func Execute() {
 func init() {
 	RootCmd.Flags().BoolVarP(&versionFlag, "version", "v", false, "Display version information")
 	RootCmd.PersistentFlags().StringVarP(&logLevelFlag, "log-level", "l", "", "Override log level (debug, info, error)")
+	RootCmd.PersistentFlags().StringVarP(&profileFlag, "profile", "p", "", "Server profile to use (from the profiles section of the config)")
 }
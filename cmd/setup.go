@@ -0,0 +1,69 @@
+/*
+Copyright © 2024 Victor Hang
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Banh-Canh/jtui/internal/config"
+	"github.com/Banh-Canh/jtui/internal/ui"
+)
+
+var setupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Configure the Jellyfin server to connect to",
+	Long: `
+Run the setup wizard to choose a Jellyfin server.
+
+Servers on the local network are discovered automatically (UDP port 7359).
+Pick one from the list or enter a URL; the server is checked before it is saved.
+Use --profile to configure a named profile instead of the default one.
+The wizard also runs automatically the first time jtui starts.`,
+	// The profile may not exist yet, so skip the profile selection of the root command
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		initConfig()
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		profile := profileFlag
+		if profile == "" {
+			profile = config.DefaultProfile
+		}
+		if !runSetup(profile) {
+			fmt.Println("Setup cancelled, the configuration was not changed.")
+		}
+	},
+}
+
+// runSetup runs the setup wizard for a profile and saves the chosen server URL.
+// It reports whether a server was saved.
+func runSetup(profile string) bool {
+	serverURL, err := ui.Setup(config.ProfileGetter(profile)("jellyfin.server_url"))
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	if serverURL == "" {
+		return false
+	}
+	if err := config.SaveServerURL(profile, serverURL); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Saved %s for profile %q\n", serverURL, profile)
+	return true
+}
+
+// runFirstRunSetup launches the setup wizard when the config file was just created
+func runFirstRunSetup() {
+	if firstRun {
+		runSetup(config.ActiveProfile())
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(setupCmd)
+}
@@ -4,30 +4,59 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/adrg/xdg"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
 	"github.com/Banh-Canh/jtui/internal/utils"
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
 )
 
 type Config struct {
-	Jellyfin JellyfinConfig `yaml:"jellyfin"`
+	Jellyfin       JellyfinConfig            `yaml:"jellyfin"`
+	Profiles       map[string]JellyfinConfig `yaml:"profiles"`
+	DefaultProfile string                    `yaml:"default_profile"`
 }
 
 type JellyfinConfig struct {
-	ServerURL string `yaml:"server_url"`
+	ServerURL    string `yaml:"server_url"`
+	AuthMethod   string `yaml:"auth_method"`
+	Username     string `yaml:"username"`
+	DownloadsDir string `yaml:"downloads_dir"`
 }
 
-// Creates the YAML config file
-func CreateDefaultConfigFile(filePath string) {
+// DefaultProfile is the name of the profile backed by the top-level jellyfin section
+const DefaultProfile = "default"
+
+// activeProfile is the profile selected at startup or from the TUI
+var activeProfile = DefaultProfile
+
+// Creates the YAML config file, reporting whether it did not exist before
+func CreateDefaultConfigFile(filePath string) bool {
 	viper.SetDefault("logLevel", "info")
 	viper.SetDefault("jellyfin", map[string]interface{}{
 		"server_url": "http://localhost:8096",
 	})
 	viper.SetConfigType("yaml")
-	viper.SafeWriteConfigAs(filePath) // nolint:all
+	return viper.SafeWriteConfigAs(filePath) == nil
+}
+
+// SaveServerURL stores the server URL of a profile and writes the config file
+func SaveServerURL(profile, serverURL string) error {
+	key := "jellyfin.server_url"
+	if profile = strings.ToLower(profile); profile != "" && profile != DefaultProfile {
+		key = "profiles." + profile + ".server_url"
+	}
+	viper.Set(key, serverURL)
+	if err := viper.WriteConfig(); err != nil {
+		return fmt.Errorf("failed to write config file: %v", err)
+	}
+	utils.Logger.Info("Saved server URL.", zap.String("profile", profile), zap.String("serverURL", serverURL))
+	return nil
 }
 
 func GetConfigDirPath() (string, error) {
@@ -50,3 +79,89 @@ func ReadConfig(filePath string) error {
 	}
 	return nil
 }
+
+// ProfileNames returns the configured profile names, the default profile first.
+// Profile names are case-insensitive and reported in lower case.
+func ProfileNames() []string {
+	var names []string
+	if viper.GetString("jellyfin.server_url") != "" {
+		names = append(names, DefaultProfile)
+	}
+	var named []string
+	for name := range viper.GetStringMap("profiles") {
+		if name != DefaultProfile {
+			named = append(named, strings.ToLower(name))
+		}
+	}
+	sort.Strings(named)
+	return append(names, named...)
+}
+
+// HasProfile reports whether a profile with the given name is configured
+func HasProfile(name string) bool {
+	return slices.Contains(ProfileNames(), strings.ToLower(name))
+}
+
+// SetActiveProfile selects the profile to connect with. An empty name selects
+// default_profile from the config, or the default profile if that is unset.
+func SetActiveProfile(name string) error {
+	if name == "" {
+		name = viper.GetString("default_profile")
+	}
+	if name == "" {
+		name = DefaultProfile
+	}
+	name = strings.ToLower(name)
+	if !HasProfile(name) {
+		return fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(ProfileNames(), ", "))
+	}
+	activeProfile = name
+	utils.Logger.Debug("Selected profile.", zap.String("profile", name))
+	return nil
+}
+
+// CheckDownloadsDirs returns an error if two profiles store their downloads in the same
+// directory, which would mix up the offline content of their servers
+func CheckDownloadsDirs() error {
+	owners := make(map[string]string)
+	for _, name := range ProfileNames() {
+		get := ProfileGetter(name)
+		dir := get("jellyfin.downloads_dir")
+		if dir == "" {
+			dir = jellyfin.DefaultDownloadsDir(get("jellyfin.profile"))
+		}
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		if owner, ok := owners[dir]; ok {
+			return fmt.Errorf("profiles %q and %q both store their downloads in %s, set a different downloads_dir for one of them",
+				owner, name, dir)
+		}
+		owners[dir] = name
+	}
+	return nil
+}
+
+// ActiveProfile returns the name of the selected profile
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// ProfileGetter returns a config lookup for the named profile. Keys under
+// "jellyfin." are resolved from the profile's section, and "jellyfin.profile"
+// yields the profile name (empty for the default profile).
+func ProfileGetter(name string) func(key string) string {
+	name = strings.ToLower(name)
+	return func(key string) string {
+		if key == "jellyfin.profile" {
+			if name == DefaultProfile {
+				return ""
+			}
+			return name
+		}
+		if rest, ok := strings.CutPrefix(key, "jellyfin."); ok && name != DefaultProfile {
+			return viper.GetString("profiles." + name + "." + rest)
+		}
+		return viper.GetString(key)
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// defaultBingeCountdown is how long the next episode is announced before it plays.
+const defaultBingeCountdown = 10 * time.Second
+
+// bingeState holds the countdown to the next episode in binge mode.
+type bingeState struct {
+	next      *jellyfin.DetailedItem // episode about to play, nil when no countdown runs
+	remaining int                    // seconds left
+	seq       uint64                 // identifies the countdown, so that stale ticks are ignored
+	stoppedID string                 // item stopped with s: its completion starts no countdown
+}
+
+// bingeEnabled reports whether the next episode plays once one finishes (config key
+// "binge", on by default).
+func bingeEnabled() bool {
+	return !viper.IsSet("binge") || viper.GetBool("binge")
+}
+
+// bingeCountdown returns the configured countdown (config key "binge_countdown").
+func bingeCountdown() time.Duration {
+	if countdown := viper.GetDuration("binge_countdown"); countdown > 0 {
+		return countdown
+	}
+	return defaultBingeCountdown
+}
+
+// resolveNextEpisode looks for the episode after a finished one, across seasons. Offline
+// episodes are ordered by the season and episode numbers of their sidecars.
+func resolveNextEpisode(client *jellyfin.Client, itemID string) tea.Cmd {
+	return func() tea.Msg {
+		var next *jellyfin.DetailedItem
+		if strings.HasPrefix(itemID, "offline-episode-") {
+			episode, err := client.Download.GetOfflineNextEpisode(itemID)
+			if err != nil {
+				return nil
+			}
+			next = episode
+		} else if !strings.HasPrefix(itemID, "offline-") && !client.IsOfflineMode() {
+			episode, err := client.Items.GetDetails(itemID)
+			if err != nil || episode.Type != "Episode" {
+				return nil
+			}
+			if next, err = client.Items.GetNextEpisode(episode); err != nil {
+				return nil
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		return bingeNextMsg{itemID: itemID, next: next}
+	}
+}
+
+func bingeTick(seq uint64) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return bingeTickMsg{seq: seq}
+	})
+}
+
+// startBinge looks for the next episode once an item finished on its own. Items of a
+// play queue are followed by the next entry instead.
+func (m model) startBinge(msg videoCompletedMsg) (model, tea.Cmd) {
+	if msg.queued || !bingeEnabled() || m.binge.stoppedID == msg.itemID {
+		return m, nil
+	}
+	// The credits prompt may already have started the next episode
+	if m.currentPlayingItem != nil && m.currentPlayingItem.GetID() != msg.itemID {
+		return m, nil
+	}
+	return m, resolveNextEpisode(m.client, msg.itemID)
+}
+
+func (m model) handleBingeNext(msg bingeNextMsg) (model, tea.Cmd) {
+	// Something else started playing meanwhile
+	playing := m.currentPlayingItem
+	if playing != nil && playing.GetID() != msg.itemID || m.binge.stoppedID == msg.itemID {
+		return m, nil
+	}
+	m.binge.seq++
+	m.binge.next = msg.next
+	m.binge.remaining = int(bingeCountdown().Round(time.Second) / time.Second)
+	return m, bingeTick(m.binge.seq)
+}
+
+func (m model) handleBingeTick(msg bingeTickMsg) (model, tea.Cmd) {
+	if m.binge.next == nil || msg.seq != m.binge.seq {
+		return m, nil
+	}
+	if m.isVideoPlaying {
+		// Something else started playing meanwhile
+		return m.cancelBinge(), nil
+	}
+	m.binge.remaining--
+	if m.binge.remaining > 0 {
+		return m, bingeTick(m.binge.seq)
+	}
+	return m.playBingeNext()
+}
+
+// playBingeNext plays the announced episode right away.
+func (m model) playBingeNext() (model, tea.Cmd) {
+	next := m.binge.next
+	m = m.cancelBinge()
+	m.currentPlayingItem = next
+	return m, tea.Batch(playItem(m.client, next.GetID(), 0), createDelayedProgressUpdateCmd())
+}
+
+func (m model) cancelBinge() model {
+	m.binge.next = nil
+	m.binge.seq++
+	return m
+}
+
+// renderBingeCountdown announces the next episode in the progress area.
+func (m model) renderBingeCountdown() string {
+	next := m.binge.next
+	name := next.GetName()
+	if season, episode := next.GetSeasonNumber(), next.GetEpisodeNumber(); episode > 0 {
+		name = fmt.Sprintf("S%02dE%02d %s", season, episode, name)
+	}
+	line := fmt.Sprintf("⏭ Next episode in %ds: %s", m.binge.remaining, name)
+	return selectedStyle.Render(line) + dimStyle.Render("  i play now • esc cancel")
+}
@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// chapterPickerState holds the state of the chapter picker (ChapterView). During
+// playback the chosen chapter is sought to, otherwise playback starts from it.
+type chapterPickerState struct {
+	item         *jellyfin.DetailedItem
+	itemID       string
+	playing      bool
+	cursor       int
+	previousView ViewType
+}
+
+// ticksToSeconds converts Jellyfin ticks (100 ns) to seconds.
+func ticksToSeconds(ticks int64) float64 {
+	return float64(ticks) / 10000000.0
+}
+
+// seekTo seeks the playing item to an absolute position through the mpv IPC.
+func seekTo(seconds float64) tea.Cmd {
+	return func() tea.Msg {
+		if err := sendMpvCommand("seek", fmt.Sprintf("%.3f", seconds), "absolute"); err != nil {
+			return errMsg{fmt.Errorf("failed to seek: %w", err)}
+		}
+		return nil
+	}
+}
+
+// openChapterPicker lists the chapters of the playing item, or of the selected one
+// when nothing plays.
+func (m model) openChapterPicker() (model, tea.Cmd) {
+	p := chapterPickerState{previousView: m.currentView}
+	switch {
+	case m.isVideoPlaying && m.currentPlayingItem != nil:
+		p.item = m.currentPlayingItem
+		p.itemID = m.currentPlayingItem.GetID()
+		p.playing = true
+		p.cursor = max(p.item.ChapterAt(int64(m.currentPlayPosition*10000000)), 0)
+	case len(m.items) > 0 && !m.items[m.cursor].GetIsFolder() && m.currentDetails != nil:
+		p.item = m.currentDetails
+		p.itemID = m.items[m.cursor].GetID()
+	default:
+		return m, nil
+	}
+	if len(p.item.Chapters) == 0 {
+		return m, nil
+	}
+	m.chapterPicker = p
+	m.currentView = ChapterView
+	return m, nil
+}
+
+func (m model) closeChapterPicker() model {
+	m.currentView = m.chapterPicker.previousView
+	m.chapterPicker = chapterPickerState{}
+	return m
+}
+
+func (m model) handleChapterKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	p := &m.chapterPicker
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "c", "backspace":
+		return m.closeChapterPicker(), nil
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.item.Chapters)-1 {
+			p.cursor++
+		}
+	case "g":
+		p.cursor = 0
+	case "G":
+		p.cursor = len(p.item.Chapters) - 1
+	case "enter", " ":
+		chapter := p.item.Chapters[p.cursor]
+		playing, itemID := p.playing, p.itemID
+		m = m.closeChapterPicker()
+		if playing {
+			return m, seekTo(ticksToSeconds(chapter.StartPositionTicks))
+		}
+		return m.playCurrent(itemID, chapter.StartPositionTicks)
+	}
+	return m, nil
+}
+
+// chapterName returns the name of a chapter, or its number when it has none.
+func chapterName(chapters []jellyfin.Chapter, index int) string {
+	if name := strings.TrimSpace(chapters[index].Name); name != "" {
+		return name
+	}
+	return fmt.Sprintf("Chapter %d", index+1)
+}
+
+func (m model) renderChapterPicker() string {
+	p := m.chapterPicker
+	var b strings.Builder
+
+	title, verb := "Start from chapter", "play"
+	if p.playing {
+		title, verb = "Jump to chapter", "jump"
+	}
+	b.WriteString(headerTitleStyle.Render(title))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(p.item.GetName()))
+	b.WriteString("\n\n")
+
+	// Show a window of chapters around the cursor
+	visible := max(m.height-12, 3)
+	start := min(max(p.cursor-visible/2, 0), max(len(p.item.Chapters)-visible, 0))
+	end := min(start+visible, len(p.item.Chapters))
+	current := -1
+	if p.playing {
+		current = p.item.ChapterAt(int64(m.currentPlayPosition * 10000000))
+	}
+	width := max(m.width-20, 20)
+	for i := start; i < end; i++ {
+		marker := "  "
+		if i == current {
+			marker = "▶ "
+		}
+		row := fmt.Sprintf("%s%8s  %s", marker, formatSeconds(ticksToSeconds(p.item.Chapters[i].StartPositionTicks)),
+			chapterName(p.item.Chapters, i))
+		row = strings.TrimRight(fitWidth(row, width), " ")
+		if i == p.cursor {
+			b.WriteString(selectedStyle.Render(row))
+		} else {
+			b.WriteString(itemStyle.Render(row))
+		}
+		if i < end-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(dimStyle.Render("↑↓/jk select • enter " + verb + " • esc back"))
+
+	box := loginBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// chapterMarks returns the cells of a progress bar of the given width where chapters
+// start, the first chapter excluded.
+func chapterMarks(item *jellyfin.DetailedItem, duration float64, width int) map[int]bool {
+	if item == nil || duration <= 0 || len(item.Chapters) < 2 {
+		return nil
+	}
+	marks := make(map[int]bool)
+	for _, chapter := range item.Chapters[1:] {
+		if cell := int(ticksToSeconds(chapter.StartPositionTicks) / duration * float64(width)); cell > 0 && cell < width {
+			marks[cell] = true
+		}
+	}
+	return marks
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -25,25 +26,36 @@ func loadLibraries(client *jellyfin.Client) tea.Cmd {
 	}
 }
 
-func loadItems(client *jellyfin.Client, parentID string, includeFolders bool) tea.Cmd {
+// loadItemsPage fetches a page of the listing of folder parentID built by
+// model.folderQuery.
+func loadItemsPage(ctx context.Context, client *jellyfin.Client, parentID string, q *jellyfin.ItemsQuery) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
 			return errMsg{fmt.Errorf("client is nil")}
 		}
-		items, err := client.Items.Get(parentID, includeFolders)
+		page, err := client.Items.QueryContext(ctx, q)
+		if ctx.Err() != nil {
+			return nil // Cancelled because the user left the folder
+		}
+		if err != nil && q.StartIndex > 0 {
+			return itemsPageFailedMsg{parentID: parentID, startIndex: q.StartIndex, err: err}
+		}
 		if err != nil {
 			return errMsg{err}
 		}
-		return itemsLoadedMsg{items}
+		return itemsPageLoadedMsg{parentID: parentID, page: page}
 	}
 }
 
-func loadItemDetails(client *jellyfin.Client, itemID string, seq uint64) tea.Cmd {
+func loadItemDetails(ctx context.Context, client *jellyfin.Client, itemID string, seq uint64) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
 			return errMsg{fmt.Errorf("client is nil")}
 		}
-		details, err := client.Items.GetDetails(itemID)
+		details, err := client.Items.GetDetailsContext(ctx, itemID)
+		if ctx.Err() != nil {
+			return nil // Cancelled because the cursor moved on
+		}
 		if err != nil {
 			return errMsg{err}
 		}
@@ -51,12 +63,15 @@ func loadItemDetails(client *jellyfin.Client, itemID string, seq uint64) tea.Cmd
 	}
 }
 
-func searchItems(client *jellyfin.Client, query string) tea.Cmd {
+func searchItems(ctx context.Context, client *jellyfin.Client, query string) tea.Cmd {
 	return func() tea.Msg {
 		if client == nil {
 			return errMsg{fmt.Errorf("client is nil")}
 		}
-		items, err := client.Search.Quick(query)
+		items, err := client.Search.QuickContext(ctx, query)
+		if ctx.Err() != nil {
+			return nil // Cancelled because the search was left or replaced
+		}
 		if err != nil {
 			return errMsg{err}
 		}
@@ -173,8 +188,9 @@ func toggleWatchedStatus(client *jellyfin.Client, itemID string, currentDetails
 
 // --- Download commands ------------------------------------------------------
 
-// downloadVideo adds a video to the download queue (non-blocking).
-func downloadVideo(client *jellyfin.Client, item *jellyfin.DetailedItem) tea.Cmd {
+// downloadVideo adds a video to the download queue (non-blocking). mediaSourceID picks
+// the version to download, the server's default one if empty.
+func downloadVideo(client *jellyfin.Client, item *jellyfin.DetailedItem, mediaSourceID string) tea.Cmd {
 	return func() tea.Msg {
 		if strings.HasPrefix(item.GetID(), "offline-") {
 			return successMsg{fmt.Sprintf("Already downloaded: %s", item.Name)}
@@ -182,7 +198,7 @@ func downloadVideo(client *jellyfin.Client, item *jellyfin.DetailedItem) tea.Cmd
 		if downloaded, filePath, err := client.Download.IsDownloaded(item); err == nil && downloaded {
 			return successMsg{fmt.Sprintf("Already downloaded: %s", filepath.Base(filePath))}
 		}
-		err := client.Download.EnqueueItem(item)
+		err := client.Download.EnqueueItemSource(item, mediaSourceID)
 		if err != nil {
 			if err.Error() == "item already in queue" {
 				return successMsg{fmt.Sprintf("Already in queue: %s", item.Name)}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+const (
+	// reconnectInterval is how often an offline client probes the server
+	reconnectInterval = 10 * time.Second
+	// connectivityCheckInterval is how often an online client checks the server is still there
+	connectivityCheckInterval = 30 * time.Second
+)
+
+// connectivityTick schedules the next probe of the connectivity monitor.
+func connectivityTick(client *jellyfin.Client) tea.Cmd {
+	interval := connectivityCheckInterval
+	if client.IsOfflineMode() {
+		interval = reconnectInterval
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return connectivityTickMsg{client: client}
+	})
+}
+
+// checkConnectivity probes the server and switches the client between online and
+// offline mode. cause is the request error that triggered an out-of-band check, if any.
+// An offline client only goes back online in Update, where the session it restores is
+// applied (see handleConnectivityChecked).
+func checkConnectivity(client *jellyfin.Client, cause error) tea.Cmd {
+	return func() tea.Msg {
+		if client.IsOfflineMode() {
+			probe, err := client.ProbeOnline()
+			return connectivityCheckedMsg{client: client, changed: err == nil, online: probe, cause: cause}
+		}
+		changed, _ := client.CheckConnectivity()
+		return connectivityCheckedMsg{client: client, changed: changed, cause: cause}
+	}
+}
+
+func (m model) handleConnectivityTick(msg connectivityTickMsg) (model, tea.Cmd) {
+	if msg.client != m.client {
+		return m, nil // Monitor of a client replaced by a profile switch
+	}
+	return m, checkConnectivity(m.client, nil)
+}
+
+func (m model) handleConnectivityChecked(msg connectivityCheckedMsg) (model, tea.Cmd) {
+	if msg.client != m.client {
+		return m, nil
+	}
+	if msg.online != nil && !m.client.IsOfflineMode() {
+		msg.changed = false // Another probe brought the client back online already
+	}
+
+	// Only the periodic probe keeps the monitor going, out-of-band checks just report
+	var next tea.Cmd
+	if msg.cause == nil {
+		next = connectivityTick(m.client)
+	}
+
+	if !msg.changed {
+		// The server is reachable, so the request failed for another reason. Requests
+		// paused by the circuit breaker are not an error: the probe resumed them.
+		if msg.cause != nil && !errors.Is(msg.cause, jellyfin.ErrCircuitOpen) {
+			m.err = msg.cause
+		}
+		return m, next
+	}
+
+	if msg.online != nil {
+		m.client.ApplyOnline(msg.online)
+	}
+	m, cmd := m.restartBrowsing()
+	return m, tea.Batch(cmd, next)
+}
+
+// restartBrowsing reloads the TUI from the libraries, or from the login screen if the
+// client needs to log in. It is used when the client went online or offline, since item
+// IDs of one mode mean nothing in the other, and when the session expired.
+func (m model) restartBrowsing() (model, tea.Cmd) {
+	m.cancelRequests()
+	if m.currentView == FilterView {
+		m.closeFilterPopup()
+	}
+	if m.currentView == GuideView {
+		m.closeGuide()
+	}
+	if m.currentView == PlaylistView {
+		m.currentView = m.playlistPicker.previousView
+		m.playlistPicker = playlistPickerState{}
+	}
+	if m.currentView == VersionView {
+		m = m.closeVersionPicker()
+	}
+	if m.currentView == ChapterView {
+		m = m.closeChapterPicker()
+	}
+	if m.currentView == SeekView {
+		m = m.closeSeekPrompt()
+	}
+	if m.currentView == KeysView {
+		m = m.closeKeysPage()
+	}
+	m.currentPath = nil
+	m.currentDetails = nil
+	m.searchQuery = ""
+	m.items = nil
+	m.allItems = nil
+	m.cursor = 0
+	m.viewportOffset = 0
+	m.cachedDownloadDirty = true
+
+	view := LibraryView
+	var cmd tea.Cmd
+	if m.client.NeedsLogin() {
+		// Back online but the saved session is gone or expired
+		view = LoginView
+		m.loading = false
+		seq := m.login.seq + 1
+		m.login = newLoginState(m.client)
+		m.login.seq = seq
+		if !m.login.usePassword {
+			cmd = startQuickConnect(m.client, seq)
+		}
+	} else {
+		m.login = loginState{seq: m.login.seq + 1} // Drop responses for a pending login
+		m.loading = true
+		cmd = loadLibraries(m.client)
+	}
+
+	if m.currentView == ProfileView {
+		m.profiles.previousView = view
+		if view == LoginView {
+			cmd = nil // Requested when the picker is closed
+		}
+	} else {
+		m.currentView = view
+	}
+	return m, cmd
+}
@@ -0,0 +1,264 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Player controls are sent to mpv over its IPC: relative seeks with the arrow keys, a
+// seek to a typed timestamp (SeekView), clicks on the progress bar, volume and speed.
+
+const (
+	shortSeek  = 10 // seconds, ←/→
+	longSeek   = 60 // seconds, shift+←/→
+	volumeStep = 5  // percent
+	speedStep  = 0.25
+	minSpeed   = 0.25
+	maxSpeed   = 4.0
+)
+
+// seekPromptState holds the timestamp typed in the seek prompt (SeekView).
+type seekPromptState struct {
+	input        string
+	err          error
+	previousView ViewType
+}
+
+// seekBy seeks the playing item relative to its position.
+func seekBy(seconds float64) tea.Cmd {
+	return func() tea.Msg {
+		if err := sendMpvCommand("seek", fmt.Sprintf("%.0f", seconds), "relative"); err != nil {
+			return errMsg{fmt.Errorf("failed to seek: %w", err)}
+		}
+		return nil
+	}
+}
+
+func changeVolume(delta int) tea.Cmd {
+	return func() tea.Msg {
+		if err := sendMpvCommand("add", "volume", strconv.Itoa(delta)); err != nil {
+			return errMsg{fmt.Errorf("failed to change the volume: %w", err)}
+		}
+		return nil
+	}
+}
+
+func toggleMute() tea.Cmd {
+	return func() tea.Msg {
+		if err := sendMpvCommand("cycle", "mute"); err != nil {
+			return errMsg{fmt.Errorf("failed to toggle mute: %w", err)}
+		}
+		return nil
+	}
+}
+
+func setSpeed(speed float64) tea.Cmd {
+	return func() tea.Msg {
+		if err := sendMpvCommand("set", "speed", strconv.FormatFloat(speed, 'f', -1, 64)); err != nil {
+			return errMsg{fmt.Errorf("failed to change the speed: %w", err)}
+		}
+		return nil
+	}
+}
+
+// getMpvControls returns the volume, the speed and the mute state of mpv.
+func getMpvControls() (volume, speed float64, muted bool) {
+	volume = getMpvFloatProperty("volume")
+	speed = getMpvFloatProperty("speed")
+	if resp, err := mpvIPCCommand([]string{"get_property", "mute"}, 1024); err == nil {
+		muted, _ = resp["data"].(bool)
+	}
+	return volume, speed, muted
+}
+
+// handleSeek seeks by a number of seconds and moves the progress bar right away,
+// without waiting for the next progress update.
+func (m model) handleSeek(seconds float64) (model, tea.Cmd) {
+	if !m.isVideoPlaying {
+		return m, nil
+	}
+	m.currentPlayPosition = max(m.currentPlayPosition+seconds, 0)
+	if m.currentPlayDuration > 0 {
+		m.currentPlayPosition = min(m.currentPlayPosition, m.currentPlayDuration)
+	}
+	return m, seekBy(seconds)
+}
+
+func (m model) handleVolume(delta int) (model, tea.Cmd) {
+	if !m.isVideoPlaying {
+		return m, nil
+	}
+	return m, changeVolume(delta)
+}
+
+func (m model) handleMute() (model, tea.Cmd) {
+	if !m.isVideoPlaying {
+		return m, nil
+	}
+	m.cachedMuted = !m.cachedMuted
+	return m, toggleMute()
+}
+
+// handleSpeed changes the playback speed by delta, or back to normal when delta is 0.
+func (m model) handleSpeed(delta float64) (model, tea.Cmd) {
+	if !m.isVideoPlaying {
+		return m, nil
+	}
+	speed := m.cachedSpeed
+	if speed <= 0 || delta == 0 {
+		speed = 1
+	}
+	speed = min(max(speed+delta, minSpeed), maxSpeed)
+	m.cachedSpeed = speed
+	return m, setSpeed(speed)
+}
+
+// controlsInfo describes the volume and the speed of mpv for the track line. The
+// speed only shows when it isn't the normal one.
+func (m model) controlsInfo() string {
+	var info string
+	switch {
+	case m.cachedMuted:
+		info += " • 🔇 muted"
+	case m.cachedVolume > 0:
+		info += fmt.Sprintf(" • 🔉 %.0f%%", m.cachedVolume)
+	}
+	if m.cachedSpeed > 0 && m.cachedSpeed != 1 {
+		info += " • ⏩ " + strconv.FormatFloat(m.cachedSpeed, 'f', -1, 64) + "×"
+	}
+	return info
+}
+
+// --- Seek prompt ------------------------------------------------------------
+
+func (m model) openSeekPrompt() (model, tea.Cmd) {
+	if !m.isVideoPlaying || m.currentPlayingItem == nil {
+		return m, nil
+	}
+	m.seekPrompt = seekPromptState{previousView: m.currentView}
+	m.currentView = SeekView
+	return m, nil
+}
+
+func (m model) closeSeekPrompt() model {
+	m.currentView = m.seekPrompt.previousView
+	m.seekPrompt = seekPromptState{}
+	return m
+}
+
+func (m model) handleSeekPromptKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	p := &m.seekPrompt
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		return m.closeSeekPrompt(), nil
+	case tea.KeyEnter:
+		seconds, err := parseTimestamp(p.input)
+		if err == nil && m.currentPlayDuration > 0 && seconds > m.currentPlayDuration {
+			err = fmt.Errorf("%s is past the end (%s)", p.input, formatSeconds(m.currentPlayDuration))
+		}
+		if err != nil {
+			p.err = err
+			return m, nil
+		}
+		m = m.closeSeekPrompt()
+		if !m.isVideoPlaying {
+			return m, nil
+		}
+		m.currentPlayPosition = seconds
+		return m, seekTo(seconds)
+	case tea.KeyBackspace:
+		if len(p.input) > 0 {
+			p.input = p.input[:len(p.input)-1]
+		}
+		p.err = nil
+	case tea.KeyRunes:
+		p.input += string(msg.Runes)
+		p.err = nil
+	}
+	return m, nil
+}
+
+// parseTimestamp parses a position typed as seconds, m:ss or h:mm:ss.
+func parseTimestamp(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	parts := strings.Split(s, ":")
+	if s == "" || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q (expected seconds, m:ss or h:mm:ss)", s)
+	}
+	seconds := 0
+	for _, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || strings.HasPrefix(part, "+") {
+			return 0, fmt.Errorf("invalid timestamp %q (expected seconds, m:ss or h:mm:ss)", s)
+		}
+		seconds = seconds*60 + n
+	}
+	return float64(seconds), nil
+}
+
+func (m model) renderSeekPrompt() string {
+	p := m.seekPrompt
+	var b strings.Builder
+
+	b.WriteString(headerTitleStyle.Render("Go to"))
+	b.WriteString("\n")
+	if m.currentPlayingItem != nil {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("%s • %s / %s", m.currentPlayingItem.GetName(),
+			formatSeconds(m.currentPlayPosition), formatSeconds(m.currentPlayDuration))))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(selectedStyle.Render(p.input + "█"))
+
+	if p.err != nil {
+		b.WriteString("\n\n")
+		b.WriteString(loginErrorStyle.Render(fmt.Sprintf("Error: %v", p.err)))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(dimStyle.Render("type a time like 1:23:45, 12:30 or 90 • enter go • esc cancel"))
+
+	box := loginBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// --- Mouse ------------------------------------------------------------------
+
+// handleMouse seeks to where the progress bar is clicked.
+func (m model) handleMouse(msg tea.MouseMsg) (model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress || msg.Button != tea.MouseButtonLeft {
+		return m, nil
+	}
+	if !m.isVideoPlaying || m.currentPlayingItem == nil || m.currentPlayDuration <= 0 || m.width < 30 {
+		return m, nil
+	}
+	if msg.Y != m.progressRow() {
+		return m, nil
+	}
+	l := m.progressLayout()
+	cell := msg.X - l.barStart
+	if cell < 0 || cell >= l.barWidth {
+		return m, nil
+	}
+	seconds := (float64(cell) + 0.5) / float64(l.barWidth) * m.currentPlayDuration
+	m.currentPlayPosition = seconds
+	return m, seekTo(seconds)
+}
+
+// progressRow returns the screen row of the progress bar, -1 when it isn't shown. It
+// follows the layout of View: the header, the panes, then the progress line.
+func (m model) progressRow() int {
+	switch m.currentView {
+	case LibraryView, FolderView, ItemView, SearchView:
+	default:
+		return -1
+	}
+	if m.err != nil || m.successMsg != "" || m.loading {
+		return -1
+	}
+	return lipgloss.Height(m.renderHeader()) + max(m.height-4, 5)
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// The Favorites virtual folder holds one virtual folder per type of favorite item.
+const (
+	favoritesFolderID    = "virtual-favorites"
+	favoritesGroupPrefix = "virtual-favorites-" // followed by the Jellyfin item type
+)
+
+// favoriteIcon marks favorite items in the list.
+const favoriteIcon = "♥ "
+
+// itemTypeLabels names the groups of the Favorites folder.
+var itemTypeLabels = map[string]string{
+	jellyfin.ItemTypeMovie:   "Movies",
+	jellyfin.ItemTypeSeries:  "Shows",
+	jellyfin.ItemTypeSeason:  "Seasons",
+	jellyfin.ItemTypeEpisode: "Episodes",
+	jellyfin.ItemTypeBoxSet:  "Collections",
+}
+
+func itemTypeLabel(itemType string) string {
+	if label, ok := itemTypeLabels[itemType]; ok {
+		return label
+	}
+	return itemType
+}
+
+// loadFavoriteGroups lists the types of the user's favorites as virtual folders.
+func loadFavoriteGroups(client *jellyfin.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return errMsg{fmt.Errorf("client is nil")}
+		}
+		favorites, err := client.Items.GetFavorites()
+		if err != nil {
+			return errMsg{err}
+		}
+
+		counts := make(map[string]int)
+		var types []string
+		for _, item := range favorites {
+			itemType := itemTypeOf(item)
+			if counts[itemType] == 0 {
+				types = append(types, itemType)
+			}
+			counts[itemType]++
+		}
+		slices.SortFunc(types, func(a, b string) int {
+			return strings.Compare(itemTypeLabel(a), itemTypeLabel(b))
+		})
+
+		groups := make([]jellyfin.Item, 0, len(types))
+		for _, itemType := range types {
+			groups = append(groups, &jellyfin.SimpleItem{
+				Name:     fmt.Sprintf("%s (%d)", itemTypeLabel(itemType), counts[itemType]),
+				ID:       favoritesGroupPrefix + itemType,
+				IsFolder: true,
+				Type:     "VirtualFolder",
+			})
+		}
+		return itemsLoadedMsg{groups}
+	}
+}
+
+// loadFavorites lists the user's favorites of one type.
+func loadFavorites(client *jellyfin.Client, itemType string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return errMsg{fmt.Errorf("client is nil")}
+		}
+		items, err := client.Items.GetFavorites(itemType)
+		if err != nil {
+			return errMsg{err}
+		}
+		return itemsLoadedMsg{items}
+	}
+}
+
+func toggleFavorite(client *jellyfin.Client, itemID string, favorite bool) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return errMsg{fmt.Errorf("client is nil")}
+		}
+		var err error
+		if favorite {
+			err = client.Playback.MarkFavorite(itemID)
+		} else {
+			err = client.Playback.UnmarkFavorite(itemID)
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		return favoriteUpdatedMsg{itemID: itemID, favorite: favorite}
+	}
+}
+
+func (m model) handleToggleFavorite() (model, tea.Cmd) {
+	if len(m.items) == 0 || m.cursor >= len(m.items) || m.client.IsOfflineMode() {
+		return m, nil
+	}
+	item := m.items[m.cursor]
+	if isVirtualFolder(item.GetID()) || strings.HasPrefix(item.GetID(), "offline-") {
+		return m, nil
+	}
+
+	d, _ := detailedOf(item)
+	favorite := d.UserData.IsFavorite
+	if m.currentDetails != nil && m.currentDetails.GetID() == item.GetID() {
+		favorite = m.currentDetails.UserData.IsFavorite
+	}
+	return m, toggleFavorite(m.client, item.GetID(), !favorite)
+}
+
+func (m model) handleFavoriteUpdated(msg favoriteUpdatedMsg) (model, tea.Cmd) {
+	if m.currentDetails != nil && m.currentDetails.GetID() == msg.itemID {
+		m.currentDetails.UserData.IsFavorite = msg.favorite
+	}
+
+	setFavorite := func(items []jellyfin.Item) {
+		for i, item := range items {
+			if item.GetID() != msg.itemID {
+				continue
+			}
+			switch di := item.(type) {
+			case jellyfin.DetailedItem:
+				di.UserData.IsFavorite = msg.favorite
+				items[i] = di
+			case *jellyfin.DetailedItem:
+				di.UserData.IsFavorite = msg.favorite
+			}
+			return
+		}
+	}
+	setFavorite(m.items)
+	setFavorite(m.allItems)
+
+	if msg.favorite {
+		m.successMsg = "Added to favorites"
+	} else {
+		m.successMsg = "Removed from favorites"
+	}
+	return m, nil
+}
@@ -0,0 +1,461 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// resolutionFilter restricts a list to videos of a given resolution.
+type resolutionFilter int
+
+const (
+	resolutionAny resolutionFilter = iota
+	resolutionSD
+	resolutionHD
+	resolution4K
+	resolutionCount
+)
+
+func (r resolutionFilter) String() string {
+	switch r {
+	case resolutionSD:
+		return "SD"
+	case resolutionHD:
+		return "HD"
+	case resolution4K:
+		return "4K"
+	default:
+		return "Any"
+	}
+}
+
+// addedWithinChoices are the choices of the "added in the last N days" filter, 0 for any.
+var addedWithinChoices = []int{0, 1, 7, 30, 90, 365}
+
+// itemFilter combines the criteria picked in the filter popup (FilterView). It is sent
+// to the server with paged folder listings and matched on the client against every
+// loaded list, which covers virtual folders and the sidecar metadata of offline content.
+type itemFilter struct {
+	genre      string
+	yearFrom   int // 0 for no lower bound
+	yearTo     int // 0 for no upper bound
+	rating     string
+	favorites  bool
+	resumable  bool
+	addedDays  int // 0 for any date
+	resolution resolutionFilter
+}
+
+func (f itemFilter) active() bool {
+	return f != itemFilter{}
+}
+
+// labels describes the active criteria for the header.
+func (f itemFilter) labels() []string {
+	var labels []string
+	if f.genre != "" {
+		labels = append(labels, f.genre)
+	}
+	switch {
+	case f.yearFrom != 0 && f.yearTo != 0:
+		labels = append(labels, fmt.Sprintf("%d–%d", f.yearFrom, f.yearTo))
+	case f.yearFrom != 0:
+		labels = append(labels, fmt.Sprintf("%d+", f.yearFrom))
+	case f.yearTo != 0:
+		labels = append(labels, fmt.Sprintf("≤%d", f.yearTo))
+	}
+	if f.rating != "" {
+		labels = append(labels, f.rating)
+	}
+	if f.favorites {
+		labels = append(labels, "Favorites")
+	}
+	if f.resumable {
+		labels = append(labels, "Resumable")
+	}
+	if f.addedDays != 0 {
+		labels = append(labels, fmt.Sprintf("Last %dd", f.addedDays))
+	}
+	if f.resolution != resolutionAny {
+		labels = append(labels, f.resolution.String())
+	}
+	return labels
+}
+
+// normalized returns the filter with its years in order, so that a range picked from the
+// end matches the same items as one picked from the start.
+func (f itemFilter) normalized() itemFilter {
+	if f.yearFrom != 0 && f.yearTo != 0 && f.yearFrom > f.yearTo {
+		f.yearFrom, f.yearTo = f.yearTo, f.yearFrom
+	}
+	return f
+}
+
+// apply adds the criteria the server can evaluate to a query.
+func (f itemFilter) apply(q *jellyfin.ItemsQuery) *jellyfin.ItemsQuery {
+	if f.genre != "" {
+		q.WithGenres(f.genre)
+	}
+	if f.yearFrom != 0 || f.yearTo != 0 {
+		var from, to time.Time
+		if f.yearFrom != 0 {
+			from = time.Date(f.yearFrom, time.January, 1, 0, 0, 0, 0, time.UTC)
+		}
+		if f.yearTo != 0 {
+			to = time.Date(f.yearTo+1, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+		}
+		q.WithPremiereDates(from, to)
+	}
+	if f.rating != "" {
+		q.WithOfficialRatings(f.rating)
+	}
+	if f.favorites {
+		q.WithFavorite(true)
+	}
+	if f.resumable {
+		q.WithResumable()
+	}
+	if f.addedDays != 0 {
+		q.WithMinDateCreated(time.Now().AddDate(0, 0, -f.addedDays))
+	}
+	switch f.resolution {
+	case resolutionSD:
+		q.WithHD(false)
+	case resolutionHD:
+		q.WithHD(true)
+	case resolution4K:
+		q.With4K(true)
+	}
+	return q
+}
+
+// matches reports whether an item meets the criteria. Seasons always match since they
+// only group the episodes of a matching series; episodes carry no genre or rating of
+// their own and folders no resolution or playback position. Items without metadata,
+// and unknown resolutions or dates, are not held against an item.
+func (f itemFilter) matches(item jellyfin.Item) bool {
+	d, ok := detailedOf(item)
+	if !ok || d.Type == jellyfin.ItemTypeSeason {
+		return true
+	}
+	isVideo := !item.GetIsFolder()
+
+	if d.Type != jellyfin.ItemTypeEpisode {
+		if f.genre != "" && !slices.ContainsFunc(d.Genres, func(g string) bool { return strings.EqualFold(g, f.genre) }) {
+			return false
+		}
+		if f.rating != "" && d.OfficialRating != f.rating {
+			return false
+		}
+	}
+	if d.ProductionYear != 0 && (f.yearFrom != 0 && d.ProductionYear < f.yearFrom || f.yearTo != 0 && d.ProductionYear > f.yearTo) {
+		return false
+	}
+	if f.favorites && !d.UserData.IsFavorite {
+		return false
+	}
+	if f.resumable && isVideo && !d.HasResumePosition() {
+		return false
+	}
+	if f.addedDays != 0 && !d.DateCreated.IsZero() &&
+		d.DateCreated.Before(time.Now().AddDate(0, 0, -f.addedDays)) {
+		return false
+	}
+	if f.resolution != resolutionAny && isVideo && (d.Width != 0 || d.Height != 0) {
+		switch f.resolution {
+		case resolutionSD:
+			return !d.IsHD()
+		case resolutionHD:
+			return d.IsHD()
+		case resolution4K:
+			return d.Is4K()
+		}
+	}
+	return true
+}
+
+// filterServerSide reports whether the filter is sent with the listing of a folder.
+// Series and seasons are listed unfiltered: the criteria describe movies and series
+// and would hide every season, so their children are only matched on the client.
+func filterServerSide(parent pathItem) bool {
+	return parent.itemType != jellyfin.ItemTypeSeries && parent.itemType != jellyfin.ItemTypeSeason
+}
+
+// Rows of the filter popup
+const (
+	filterRowGenre = iota
+	filterRowYearFrom
+	filterRowYearTo
+	filterRowRating
+	filterRowFavorites
+	filterRowResumable
+	filterRowAdded
+	filterRowResolution
+	filterRowCount
+)
+
+// filterPopupState holds the state of the filter popup (FilterView).
+type filterPopupState struct {
+	draft        itemFilter
+	row          int
+	options      jellyfin.FilterOptions
+	loading      bool
+	err          error
+	cancel       context.CancelFunc
+	previousView ViewType
+}
+
+// loadFilterOptions fetches the genres, ratings and years to offer in the filter popup.
+func loadFilterOptions(ctx context.Context, client *jellyfin.Client, parentID string) tea.Cmd {
+	return func() tea.Msg {
+		options, err := client.Items.GetFilterOptionsContext(ctx, parentID)
+		if ctx.Err() != nil {
+			return nil // The popup was closed
+		}
+		return filterOptionsLoadedMsg{options: options, err: err}
+	}
+}
+
+// filterOptionsFrom collects the genres, ratings and years of loaded items. It stands
+// in for the server in offline mode and in virtual folders.
+func filterOptionsFrom(items []jellyfin.Item) jellyfin.FilterOptions {
+	var options jellyfin.FilterOptions
+	for _, item := range items {
+		d, ok := detailedOf(item)
+		if !ok {
+			continue
+		}
+		for _, genre := range d.Genres {
+			if !slices.Contains(options.Genres, genre) {
+				options.Genres = append(options.Genres, genre)
+			}
+		}
+		if d.OfficialRating != "" && !slices.Contains(options.OfficialRatings, d.OfficialRating) {
+			options.OfficialRatings = append(options.OfficialRatings, d.OfficialRating)
+		}
+		if d.ProductionYear != 0 && !slices.Contains(options.Years, d.ProductionYear) {
+			options.Years = append(options.Years, d.ProductionYear)
+		}
+	}
+	return options
+}
+
+func (m model) openFilterPopup() (model, tea.Cmd) {
+	m.filterPopup = filterPopupState{
+		draft:        m.itemFilter,
+		previousView: m.currentView,
+	}
+	m.currentView = FilterView
+
+	var parentID string
+	if len(m.currentPath) > 0 {
+		parentID = m.currentPath[len(m.currentPath)-1].id
+	}
+	if m.client.IsOfflineMode() || isVirtualFolder(parentID) || strings.HasPrefix(parentID, "offline-") {
+		m.filterPopup.options = filterOptionsFrom(m.allItems)
+		m.filterPopup.sortOptions()
+		return m, nil
+	}
+
+	var ctx context.Context
+	ctx, m.filterPopup.cancel = context.WithCancel(context.Background())
+	m.filterPopup.loading = true
+	return m, loadFilterOptions(ctx, m.client, parentID)
+}
+
+func (m model) handleFilterOptionsLoaded(msg filterOptionsLoadedMsg) (model, tea.Cmd) {
+	if m.currentView != FilterView {
+		return m, nil
+	}
+	m.filterPopup.loading = false
+	if msg.err != nil {
+		m.filterPopup.err = msg.err
+		m.filterPopup.options = filterOptionsFrom(m.allItems)
+	} else {
+		m.filterPopup.options = *msg.options
+	}
+	m.filterPopup.sortOptions()
+	return m, nil
+}
+
+func (p *filterPopupState) sortOptions() {
+	slices.SortFunc(p.options.Genres, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	slices.Sort(p.options.OfficialRatings)
+	slices.Sort(p.options.Years)
+}
+
+// closeFilterPopup returns to the view the popup was opened from.
+func (m *model) closeFilterPopup() {
+	if m.filterPopup.cancel != nil {
+		m.filterPopup.cancel()
+	}
+	m.currentView = m.filterPopup.previousView
+	m.filterPopup = filterPopupState{}
+}
+
+func (m model) handleFilterKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	popup := &m.filterPopup
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "F":
+		m.closeFilterPopup()
+	case "up", "k":
+		if popup.row > 0 {
+			popup.row--
+		}
+	case "down", "j":
+		if popup.row < filterRowCount-1 {
+			popup.row++
+		}
+	case "left", "h":
+		popup.change(-1)
+	case "right", "l", " ":
+		popup.change(1)
+	case "c":
+		popup.draft = itemFilter{}
+	case "enter":
+		filter := popup.draft.normalized()
+		m.closeFilterPopup()
+		return m.setItemFilter(filter)
+	}
+	return m, nil
+}
+
+// change moves the value of the selected row to the previous or next choice.
+func (p *filterPopupState) change(delta int) {
+	f := &p.draft
+	switch p.row {
+	case filterRowGenre:
+		f.genre = cycle(append([]string{""}, p.options.Genres...), f.genre, delta)
+	case filterRowYearFrom:
+		f.yearFrom = cycle(append([]int{0}, p.options.Years...), f.yearFrom, delta)
+	case filterRowYearTo:
+		f.yearTo = cycle(append([]int{0}, p.options.Years...), f.yearTo, delta)
+	case filterRowRating:
+		f.rating = cycle(append([]string{""}, p.options.OfficialRatings...), f.rating, delta)
+	case filterRowFavorites:
+		f.favorites = !f.favorites
+	case filterRowResumable:
+		f.resumable = !f.resumable
+	case filterRowAdded:
+		f.addedDays = cycle(addedWithinChoices, f.addedDays, delta)
+	case filterRowResolution:
+		f.resolution = (f.resolution + resolutionFilter(delta) + resolutionCount) % resolutionCount
+	}
+}
+
+// cycle returns the choice delta steps away from current, wrapping around.
+func cycle[T comparable](choices []T, current T, delta int) T {
+	i := max(slices.Index(choices, current), 0)
+	return choices[((i+delta)%len(choices)+len(choices))%len(choices)]
+}
+
+// setItemFilter applies a new filter to the current list. Paged folders are filtered
+// by the server and fetched again; other lists are filtered on the client.
+func (m model) setItemFilter(filter itemFilter) (model, tea.Cmd) {
+	m.itemFilter = filter
+	if m.pagedParentID != "" && len(m.currentPath) > 0 {
+		m.cancelRequests()
+		m.loading = true
+		cmd := m.loadCurrentFolder()
+		return m, cmd
+	}
+
+	m.applyFilter()
+	if len(m.items) > 0 && m.cursor < len(m.items) {
+		itemID := m.items[m.cursor].GetID()
+		if !isVirtualFolder(itemID) {
+			m.detailSeq++
+			return m, loadItemDetails(m.detailContext(), m.client, itemID, m.detailSeq)
+		}
+	}
+	return m, nil
+}
+
+// filterLabels lists every active filter for the header.
+func (m model) filterLabels() []string {
+	var labels []string
+	if m.filter != FilterAll {
+		labels = append(labels, m.filter.String())
+	}
+	return append(labels, m.itemFilter.labels()...)
+}
+
+func (m model) renderFilterPopup() string {
+	var b strings.Builder
+	popup := m.filterPopup
+	f := popup.draft
+
+	b.WriteString(headerTitleStyle.Render("󰈲 JTUI — Filter"))
+	b.WriteString("\n\n")
+
+	orAny := func(value string) string {
+		if value == "" {
+			return "Any"
+		}
+		return value
+	}
+	year := func(year int) string {
+		if year == 0 {
+			return "Any"
+		}
+		return fmt.Sprint(year)
+	}
+	onOff := func(on bool) string {
+		if on {
+			return "Yes"
+		}
+		return "No"
+	}
+	added := "Any"
+	if f.addedDays != 0 {
+		added = fmt.Sprintf("Last %d days", f.addedDays)
+	}
+
+	rows := [filterRowCount][2]string{
+		filterRowGenre:      {"Genre", orAny(f.genre)},
+		filterRowYearFrom:   {"Year from", year(f.yearFrom)},
+		filterRowYearTo:     {"Year to", year(f.yearTo)},
+		filterRowRating:     {"Rating", orAny(f.rating)},
+		filterRowFavorites:  {"Favorites only", onOff(f.favorites)},
+		filterRowResumable:  {"Resumable only", onOff(f.resumable)},
+		filterRowAdded:      {"Added", added},
+		filterRowResolution: {"Resolution", f.resolution.String()},
+	}
+	for i, row := range rows {
+		line := fmt.Sprintf("%-15s ‹ %s ›", row[0], row[1])
+		if i == popup.row {
+			b.WriteString(selectedStyle.Render(line))
+		} else {
+			b.WriteString(itemStyle.Render(line))
+		}
+		if i < len(rows)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	if popup.loading {
+		b.WriteString("\n\n")
+		b.WriteString(infoStyle.Render("Loading genres, ratings and years..."))
+	}
+	if popup.err != nil {
+		b.WriteString("\n\n")
+		b.WriteString(loginErrorStyle.Render(fmt.Sprintf("Error: %v", popup.err)))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(dimStyle.Render("↑↓/jk select • ←→/hl change • c clear • enter apply • esc cancel"))
+
+	box := loginBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyGroup is a section of the key reference (KeysView).
+type keyGroup struct {
+	title string
+	keys  [][2]string // key, what it does
+}
+
+// keyGroups lists every key of the lists, which the help line only samples.
+var keyGroups = []keyGroup{
+	{"Browsing", [][2]string{
+		{"↑↓ / j k", "move"},
+		{"g / G", "top / bottom"},
+		{"PgUp / PgDn", "page up / down"},
+		{"Enter", "open or play"},
+		{"h / Backspace", "back"},
+		{"/", "search"},
+		{"f / F", "cycle filter / filter by..."},
+		{"o", "cycle sort order"},
+		{"P", "switch profile"},
+		{"q", "quit"},
+	}},
+	{"Items", [][2]string{
+		{"Space", "play, or play all"},
+		{"r", "resume"},
+		{"S", "shuffle"},
+		{"c", "chapters"},
+		{"d", "download"},
+		{"w", "toggle watched"},
+		{"*", "toggle favorite"},
+		{"+", "add to a playlist"},
+		{"-", "remove from the playlist"},
+		{"[ / ]", "move playlist entry up / down"},
+	}},
+	{"Playback", [][2]string{
+		{"Space / r", "pause / resume"},
+		{"s", "stop"},
+		{"← / →", "seek 10s"},
+		{"Shift+← / →", "seek 60s"},
+		{"T", "go to a time"},
+		{"9 / 0", "volume down / up"},
+		{"m", "mute"},
+		{"{ / } / =", "slower / faster / normal speed"},
+		{"u / a", "cycle subtitles / audio"},
+		{"< / >", "previous / next in the queue"},
+		{"i", "skip the segment, or play the next episode"},
+		{"Esc", "cancel the next episode"},
+	}},
+}
+
+// keysPageState holds the state of the key reference (KeysView).
+type keysPageState struct {
+	previousView ViewType
+}
+
+func (m model) openKeysPage() (model, tea.Cmd) {
+	m.keysPage = keysPageState{previousView: m.currentView}
+	m.currentView = KeysView
+	return m, nil
+}
+
+func (m model) closeKeysPage() model {
+	m.currentView = m.keysPage.previousView
+	m.keysPage = keysPageState{}
+	return m
+}
+
+func (m model) handleKeysPageKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "?", "backspace":
+		return m.closeKeysPage(), nil
+	}
+	return m, nil
+}
+
+// renderKeyGroup renders a group of keys with their descriptions aligned.
+func renderKeyGroup(group keyGroup) string {
+	width := 0
+	for _, key := range group.keys {
+		width = max(width, lipgloss.Width(key[0]))
+	}
+	var b strings.Builder
+	b.WriteString(itemStyle.Render(group.title))
+	for _, key := range group.keys {
+		b.WriteString("\n")
+		b.WriteString(selectedStyle.Render(fmt.Sprintf("%-*s", width, key[0])) + "  " + dimStyle.Render(key[1]))
+	}
+	return b.String()
+}
+
+// renderKeysPage renders the playback keys next to the others, or every group one
+// under the other when the terminal is too narrow.
+func (m model) renderKeysPage() string {
+	groups := make([]string, len(keyGroups))
+	for i, group := range keyGroups {
+		groups[i] = renderKeyGroup(group)
+	}
+	last := len(groups) - 1
+	left := lipgloss.NewStyle().PaddingRight(4).Render(strings.Join(groups[:last], "\n\n"))
+	body := lipgloss.JoinHorizontal(lipgloss.Top, left, groups[last])
+	if lipgloss.Width(body)+loginBoxStyle.GetHorizontalFrameSize() > m.width {
+		body = strings.Join(groups, "\n\n")
+	}
+
+	var b strings.Builder
+	b.WriteString(headerTitleStyle.Render("Keys"))
+	b.WriteString("\n\n")
+	b.WriteString(body)
+	b.WriteString("\n\n")
+	b.WriteString(dimStyle.Render("esc close"))
+
+	box := loginBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,518 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// liveTvFolderID is the virtual folder opening the program guide (GuideView).
+const liveTvFolderID = "virtual-live-tv"
+
+// guideTab is a tab of the guide view.
+type guideTab int
+
+const (
+	guideTabChannels guideTab = iota
+	guideTabRecordings
+	guideTabTimers
+	guideTabCount
+)
+
+var guideTabNames = [guideTabCount]string{"Guide", "Recordings", "Scheduled"}
+
+// guideState holds the state of the live TV guide (GuideView).
+type guideState struct {
+	tab          guideTab
+	entries      []jellyfin.GuideEntry
+	recordings   []jellyfin.Item
+	timers       []jellyfin.Timer
+	at           time.Time // time the now/next columns were computed for
+	cursor       int
+	next         bool // the Next column is selected rather than Now
+	loading      bool
+	notice       string
+	err          error
+	previousView ViewType
+}
+
+// rows returns the number of rows of the current tab.
+func (g *guideState) rows() int {
+	switch g.tab {
+	case guideTabRecordings:
+		return len(g.recordings)
+	case guideTabTimers:
+		return len(g.timers)
+	}
+	return len(g.entries)
+}
+
+// selectedProgram returns the program under the cursor of the Guide tab, nil if none.
+func (g *guideState) selectedProgram() *jellyfin.Program {
+	if g.tab != guideTabChannels || g.cursor >= len(g.entries) {
+		return nil
+	}
+	if g.next {
+		return g.entries[g.cursor].Next
+	}
+	return g.entries[g.cursor].Now
+}
+
+func loadGuideTab(client *jellyfin.Client, tab guideTab) tea.Cmd {
+	return func() tea.Msg {
+		msg := guideLoadedMsg{tab: tab, at: time.Now()}
+		switch tab {
+		case guideTabChannels:
+			msg.entries, msg.err = client.LiveTv.GetGuide(msg.at)
+		case guideTabRecordings:
+			msg.recordings, msg.err = client.LiveTv.GetRecordings()
+		case guideTabTimers:
+			msg.timers, msg.err = client.LiveTv.GetTimers()
+		}
+		return msg
+	}
+}
+
+func recordProgram(client *jellyfin.Client, program jellyfin.Program) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.LiveTv.RecordProgram(program.ID); err != nil {
+			return timerUpdatedMsg{err: fmt.Errorf("failed to schedule recording: %w", err)}
+		}
+		return timerUpdatedMsg{message: "Recording " + program.Title()}
+	}
+}
+
+func cancelTimer(client *jellyfin.Client, timerID, name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.LiveTv.CancelTimer(timerID); err != nil {
+			return timerUpdatedMsg{err: fmt.Errorf("failed to cancel recording: %w", err)}
+		}
+		return timerUpdatedMsg{message: "Cancelled the recording of " + name}
+	}
+}
+
+func cancelSeriesTimer(client *jellyfin.Client, seriesTimerID, name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.LiveTv.CancelSeriesTimer(seriesTimerID); err != nil {
+			return timerUpdatedMsg{err: fmt.Errorf("failed to cancel series recording: %w", err)}
+		}
+		return timerUpdatedMsg{message: "Cancelled the series recording of " + name}
+	}
+}
+
+// playChannel opens a live stream of the channel and plays it in mpv. The stream is
+// closed when mpv exits, which frees the tuner on the server.
+func playChannel(client *jellyfin.Client, entry jellyfin.GuideEntry) tea.Cmd {
+	return func() tea.Msg {
+		closeRunningMpv()
+
+		stream, err := client.LiveTv.OpenStream(entry.Channel.ID)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		title := entry.Channel.Name
+		if entry.Now != nil {
+			title += " — " + entry.Now.Title()
+		}
+		cmd := exec.Command("mpv",
+			"--input-ipc-server="+mpvSocketPath, "--title=jtui-player", "--force-media-title="+title, stream.URL)
+		if err := cmd.Start(); err != nil {
+			client.LiveTv.CloseStream(stream)
+			return errMsg{fmt.Errorf("failed to start mpv: %w", err)}
+		}
+		registerMpvProcess(cmd)
+		client.Playback.ReportStart(stream)
+
+		go func() {
+			runErr := cmd.Wait()
+			tracked := unregisterMpvProcess(cmd)
+			client.Playback.ReportStop(stream, 0)
+			client.LiveTv.CloseStream(stream)
+			// Switching channels kills the previous player, which is no failure
+			if runErr != nil && tracked && globalProgram != nil {
+				globalProgram.Send(errMsg{fmt.Errorf("mpv playback failed: %w", runErr)})
+			}
+		}()
+
+		item := &jellyfin.DetailedItem{}
+		item.ID = entry.Channel.ID
+		item.Name = title
+		item.Type = "TvChannel"
+		return liveStreamStartedMsg{item: item}
+	}
+}
+
+func (m model) openGuide() (model, tea.Cmd) {
+	if m.client.IsOfflineMode() {
+		return m, nil
+	}
+	m.guide = guideState{
+		loading:      true,
+		previousView: m.currentView,
+	}
+	m.currentView = GuideView
+	return m, loadGuideTab(m.client, guideTabChannels)
+}
+
+func (m *model) closeGuide() {
+	m.currentView = m.guide.previousView
+	m.guide = guideState{}
+}
+
+func (m model) handleGuideLoaded(msg guideLoadedMsg) (model, tea.Cmd) {
+	if m.currentView != GuideView || msg.tab != m.guide.tab {
+		return m, nil
+	}
+	g := &m.guide
+	g.loading = false
+	g.err = msg.err
+	switch msg.tab {
+	case guideTabChannels:
+		g.entries, g.at = msg.entries, msg.at
+	case guideTabRecordings:
+		g.recordings = msg.recordings
+	case guideTabTimers:
+		g.timers = msg.timers
+	}
+	g.cursor = max(min(g.cursor, g.rows()-1), 0)
+	return m, nil
+}
+
+// handleTimerUpdated shows the outcome of scheduling or cancelling a recording and
+// reloads the tab to show it.
+func (m model) handleTimerUpdated(msg timerUpdatedMsg) (model, tea.Cmd) {
+	if m.currentView != GuideView {
+		return m, nil
+	}
+	g := &m.guide
+	if msg.err != nil {
+		g.loading = false
+		g.err = msg.err
+		return m, nil
+	}
+	g.notice = msg.message
+	return m, loadGuideTab(m.client, g.tab)
+}
+
+func (m model) handleGuideKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	g := &m.guide
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "backspace":
+		m.closeGuide()
+		return m, nil
+	case "s":
+		if m.isVideoPlaying {
+			return m, stopPlayback()
+		}
+		return m, nil
+	}
+	if g.loading {
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "tab", "shift+tab":
+		if msg.String() == "tab" {
+			g.tab = (g.tab + 1) % guideTabCount
+		} else {
+			g.tab = (g.tab + guideTabCount - 1) % guideTabCount
+		}
+		g.cursor, g.next, g.notice, g.err = 0, false, "", nil
+		g.loading = true
+		return m, loadGuideTab(m.client, g.tab)
+	case "R":
+		g.notice, g.err = "", nil
+		g.loading = true
+		return m, loadGuideTab(m.client, g.tab)
+	case "up", "k":
+		if g.cursor > 0 {
+			g.cursor--
+		}
+	case "down", "j":
+		if g.cursor < g.rows()-1 {
+			g.cursor++
+		}
+	case "g":
+		g.cursor = 0
+	case "G":
+		g.cursor = max(g.rows()-1, 0)
+	case "left", "h":
+		g.next = false
+	case "right", "l":
+		g.next = true
+	case "enter", " ":
+		return m.playGuideSelection()
+	case "r":
+		if program := g.selectedProgram(); program != nil {
+			g.notice, g.err = "", nil
+			g.loading = true
+			// A program recorded with its series only has a timer of its own once the
+			// server scheduled it; until then the whole series recording is cancelled
+			switch {
+			case program.TimerID != "":
+				return m, cancelTimer(m.client, program.TimerID, program.Title())
+			case program.SeriesTimerID != "":
+				return m, cancelSeriesTimer(m.client, program.SeriesTimerID, program.Name)
+			}
+			return m, recordProgram(m.client, *program)
+		}
+	case "d":
+		if g.tab == guideTabTimers && g.cursor < len(g.timers) {
+			timer := g.timers[g.cursor]
+			g.notice, g.err = "", nil
+			g.loading = true
+			return m, cancelTimer(m.client, timer.ID, timer.Name)
+		}
+	}
+	return m, nil
+}
+
+// playGuideSelection plays the channel or the recording under the cursor.
+func (m model) playGuideSelection() (model, tea.Cmd) {
+	g := &m.guide
+	switch g.tab {
+	case guideTabChannels:
+		if g.cursor < len(g.entries) {
+			return m, playChannel(m.client, g.entries[g.cursor])
+		}
+	case guideTabRecordings:
+		if g.cursor < len(g.recordings) {
+			recording := g.recordings[g.cursor]
+			d, _ := detailedOf(recording)
+			m.currentPlayingItem = &d
+			return m, tea.Batch(playItem(m.client, recording.GetID(), 0), createDelayedProgressUpdateCmd())
+		}
+	}
+	return m, nil
+}
+
+// --- Rendering --------------------------------------------------------------
+
+// fitWidth truncates or pads s to exactly width runes.
+func fitWidth(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) > width {
+		if width == 1 {
+			return "…"
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// programBar is a small bar showing how much of a program has aired.
+func programBar(progress float64, width int) string {
+	filled := int(progress * float64(width))
+	return strings.Repeat("▰", filled) + strings.Repeat("▱", width-filled)
+}
+
+func (m model) renderGuide() string {
+	g := m.guide
+	header := m.renderHeader()
+
+	tabs := make([]string, 0, guideTabCount)
+	for tab, name := range guideTabNames {
+		if guideTab(tab) == g.tab {
+			tabs = append(tabs, selectedStyle.Render(" "+name+" "))
+		} else {
+			tabs = append(tabs, itemStyle.Render(" "+name+" "))
+		}
+	}
+	tabBar := headerTitleStyle.Render("󰐻 Live TV") + "  " + strings.Join(tabs, " ")
+
+	var status string
+	switch {
+	case g.loading:
+		status = infoStyle.Render("Loading...")
+	case g.err != nil:
+		status = loginErrorStyle.Render(fmt.Sprintf("Error: %v", g.err))
+	case g.notice != "":
+		status = infoStyle.Render(g.notice)
+	}
+
+	var help string
+	switch g.tab {
+	case guideTabChannels:
+		help = "↑↓/jk channel • ←→/hl now/next • Enter play • r record/cancel • R refresh • Tab next tab • Esc back"
+	case guideTabRecordings:
+		help = "↑↓/jk select • Enter play • R refresh • Tab next tab • Esc back"
+	case guideTabTimers:
+		help = "↑↓/jk select • d cancel recording • R refresh • Tab next tab • Esc back"
+	}
+	help = dimStyle.Render(lipgloss.NewStyle().Width(max(m.width-2, 10)).Render(help))
+
+	var progress string
+	if m.isVideoPlaying && m.currentPlayingItem != nil {
+		progress = m.renderProgressBar()
+	}
+
+	// Rows left for the tab content after the header, tab bar, status, help and progress
+	height := m.height - lipgloss.Height(header) - lipgloss.Height(help) - 4
+	if progress != "" {
+		height -= lipgloss.Height(progress)
+	}
+	height = max(height, 3)
+
+	var body string
+	switch g.tab {
+	case guideTabChannels:
+		body = m.renderGuideChannels(height)
+	case guideTabRecordings:
+		body = m.renderGuideRecordings(height)
+	case guideTabTimers:
+		body = m.renderGuideTimers(height)
+	}
+	body = lipgloss.NewStyle().Height(height).Render(body)
+
+	parts := []string{header, tabBar, status, body}
+	if progress != "" {
+		parts = append(parts, progress)
+	}
+	parts = append(parts, "", help)
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
+
+// guideWindowRows returns the first row to show so that the cursor stays visible.
+func guideWindowRows(cursor, rows, height int) (int, int) {
+	start := 0
+	if cursor >= height {
+		start = cursor - height + 1
+	}
+	return start, min(start+height, rows)
+}
+
+// renderGuideChannels renders the Guide tab: one row per channel with the program it
+// airs now and the next one, the selected program being detailed below.
+func (m model) renderGuideChannels(height int) string {
+	g := m.guide
+	if len(g.entries) == 0 {
+		if g.loading {
+			return ""
+		}
+		return dimStyle.Render("No channels. Check the tuners and guide data of the server.")
+	}
+
+	const numberWidth, nameWidth, barWidth = 6, 18, 8
+	columnWidth := max((m.width-numberWidth-nameWidth-6)/2, 20)
+	var b strings.Builder
+
+	b.WriteString(dimStyle.Render(
+		fitWidth("#", numberWidth) + fitWidth("Channel", nameWidth) + "  " +
+			fitWidth("Now", columnWidth) + "  " + fitWidth("Next", columnWidth)))
+	b.WriteString("\n")
+
+	cell := func(program *jellyfin.Program, airing bool) string {
+		if program == nil {
+			return "—"
+		}
+		text := program.StartDate.Local().Format("15:04") + " " + program.Title()
+		if program.TimerID != "" || program.SeriesTimerID != "" {
+			text = "● " + text
+		}
+		if airing {
+			return programBar(program.Progress(g.at), barWidth) + " " + text
+		}
+		return text
+	}
+
+	start, end := guideWindowRows(g.cursor, len(g.entries), height-3)
+	for i := start; i < end; i++ {
+		entry := g.entries[i]
+		channel := fitWidth(entry.Channel.Number, numberWidth) + fitWidth(entry.Channel.Name, nameWidth)
+		now := fitWidth(cell(entry.Now, true), columnWidth)
+		next := fitWidth(cell(entry.Next, false), columnWidth)
+
+		if i == g.cursor {
+			b.WriteString(itemStyle.Render(channel) + "  ")
+			if g.next {
+				b.WriteString(dimStyle.Render(now) + "  " + selectedStyle.Render(next))
+			} else {
+				b.WriteString(selectedStyle.Render(now) + "  " + dimStyle.Render(next))
+			}
+		} else {
+			b.WriteString(itemStyle.Render(channel + "  " + now + "  " + next))
+		}
+		b.WriteString("\n")
+	}
+
+	if program := g.selectedProgram(); program != nil {
+		b.WriteString("\n")
+		summary := fmt.Sprintf("%s–%s  %s",
+			program.StartDate.Local().Format("15:04"), program.EndDate.Local().Format("15:04"), program.Title())
+		if program.Overview != "" {
+			summary += " — " + program.Overview
+		}
+		b.WriteString(infoStyle.Render(fitWidth(summary, max(m.width-2, 10))))
+	}
+	return b.String()
+}
+
+func (m model) renderGuideRecordings(height int) string {
+	g := m.guide
+	if len(g.recordings) == 0 {
+		if g.loading {
+			return ""
+		}
+		return dimStyle.Render("No recordings.")
+	}
+
+	var b strings.Builder
+	start, end := guideWindowRows(g.cursor, len(g.recordings), height)
+	for i := start; i < end; i++ {
+		d, _ := detailedOf(g.recordings[i])
+		line := fmt.Sprintf("%s  %s", d.DateCreated.Local().Format("Mon 02 Jan 15:04"), d.GetName())
+		if runtime := d.GetRuntime(); runtime != "" {
+			line += "  (" + runtime + ")"
+		}
+		line = fitWidth(line, max(m.width-4, 10))
+		if i == g.cursor {
+			b.WriteString(selectedStyle.Render(line))
+		} else {
+			b.WriteString(itemStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (m model) renderGuideTimers(height int) string {
+	g := m.guide
+	if len(g.timers) == 0 {
+		if g.loading {
+			return ""
+		}
+		return dimStyle.Render("No scheduled recordings. Press r on a program of the guide to record it.")
+	}
+
+	var b strings.Builder
+	start, end := guideWindowRows(g.cursor, len(g.timers), height)
+	for i := start; i < end; i++ {
+		timer := g.timers[i]
+		line := fmt.Sprintf("%s–%s  %s  %s",
+			timer.StartDate.Local().Format("Mon 02 Jan 15:04"), timer.EndDate.Local().Format("15:04"),
+			timer.ChannelName, timer.Name)
+		if timer.Status != "" && timer.Status != "New" {
+			line += "  [" + timer.Status + "]"
+		}
+		line = fitWidth(line, max(m.width-4, 10))
+		if i == g.cursor {
+			b.WriteString(selectedStyle.Render(line))
+		} else {
+			b.WriteString(itemStyle.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
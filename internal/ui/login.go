@@ -0,0 +1,397 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// loginState holds the state of the login screen (LoginView).
+type loginState struct {
+	quickConnect *jellyfin.QuickConnectData
+	seq          uint64 // bumped on regenerate/cancel so responses for old codes are dropped
+	polling      bool
+	lastPoll     time.Time
+	now          time.Time // time of the last countdown tick
+	cancelled    bool
+	// Password login form
+	usePassword   bool
+	username      string
+	password      string // kept in memory only until the login attempt returns
+	passwordFocus bool
+	submitting    bool
+	status        string
+	err           error
+}
+
+// newLoginState prepares the login screen according to the configured auth method.
+func newLoginState(client *jellyfin.Client) loginState {
+	cfg := client.GetConfig()
+	return loginState{
+		usePassword:   cfg.AuthMethod == jellyfin.AuthMethodPassword,
+		username:      cfg.Username,
+		passwordFocus: cfg.Username != "",
+		now:           time.Now(),
+	}
+}
+
+// --- Commands ---------------------------------------------------------------
+
+func startQuickConnect(client *jellyfin.Client, seq uint64) tea.Cmd {
+	client.Auth.EnsureDeviceID()
+	return func() tea.Msg {
+		data, err := client.Auth.StartQuickConnect()
+		return quickConnectStartedMsg{data: data, seq: seq, err: err}
+	}
+}
+
+// pollQuickConnect checks the code once. The credentials are applied to the client in
+// Update, not here, since commands run concurrently with it.
+func pollQuickConnect(client *jellyfin.Client, data *jellyfin.QuickConnectData, seq uint64) tea.Cmd {
+	return func() tea.Msg {
+		accessToken, userID, err := client.Auth.QuickConnectCredentials(data)
+		return quickConnectPolledMsg{seq: seq, accessToken: accessToken, userID: userID, err: err}
+	}
+}
+
+func loginTick(seq uint64) tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return loginTickMsg{seq: seq, now: t}
+	})
+}
+
+// passwordLogin logs in with a password. Like the Quick Connect polls, it leaves the
+// credentials to Update (see handleLoginSucceeded).
+func passwordLogin(client *jellyfin.Client, username, password string) tea.Cmd {
+	client.Auth.EnsureDeviceID()
+	return func() tea.Msg {
+		accessToken, userID, err := client.Auth.PasswordCredentials(username, password)
+		if err != nil {
+			return passwordLoginFailedMsg{err: err}
+		}
+		return loginSucceededMsg{accessToken: accessToken, userID: userID}
+	}
+}
+
+// --- Message handlers -------------------------------------------------------
+
+func (m model) handleQuickConnectStarted(msg quickConnectStartedMsg) (model, tea.Cmd) {
+	if msg.seq != m.login.seq || m.login.usePassword {
+		return m, nil
+	}
+	if msg.err != nil {
+		if errors.Is(msg.err, jellyfin.ErrQuickConnectDisabled) &&
+			m.client.GetConfig().AuthMethod != jellyfin.AuthMethodQuickConnect {
+			m.login.usePassword = true
+			m.login.status = "Quick Connect is disabled on this server, log in with your password."
+			return m, nil
+		}
+		m.login.err = msg.err
+		return m, nil
+	}
+	m.login.quickConnect = msg.data
+	m.login.now = time.Now()
+	m.login.lastPoll = m.login.now
+	m.login.err = nil
+	m.login.status = ""
+	return m, loginTick(m.login.seq)
+}
+
+func (m model) handleLoginTick(msg loginTickMsg) (model, tea.Cmd) {
+	if msg.seq != m.login.seq || m.login.quickConnect == nil || m.login.cancelled {
+		return m, nil
+	}
+	m.login.now = msg.now
+	if !msg.now.Before(m.login.quickConnect.ExpiresAt) {
+		m.login.status = "Code expired. Press 'r' to get a new one."
+		return m, nil
+	}
+
+	cmds := []tea.Cmd{loginTick(m.login.seq)}
+	if !m.login.polling && msg.now.Sub(m.login.lastPoll) >= jellyfin.QuickConnectPollInterval {
+		m.login.polling = true
+		m.login.lastPoll = msg.now
+		cmds = append(cmds, pollQuickConnect(m.client, m.login.quickConnect, m.login.seq))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) handleQuickConnectPolled(msg quickConnectPolledMsg) (model, tea.Cmd) {
+	if msg.seq != m.login.seq {
+		return m, nil
+	}
+	m.login.polling = false
+	if msg.err != nil {
+		// Transient failures are retried on the next tick
+		m.login.err = msg.err
+		return m, nil
+	}
+	m.login.err = nil
+	if msg.accessToken != "" {
+		return m.handleLoginSucceeded(loginSucceededMsg{accessToken: msg.accessToken, userID: msg.userID})
+	}
+	return m, nil
+}
+
+func (m model) handlePasswordLoginFailed(msg passwordLoginFailedMsg) (model, tea.Cmd) {
+	m.login.submitting = false
+	m.login.password = ""
+	m.login.err = msg.err
+	return m, nil
+}
+
+// handleLoginSucceeded applies and persists the new session, then leaves the login
+// screen. Ticks and polls still in flight for the code are dropped.
+func (m model) handleLoginSucceeded(msg loginSucceededMsg) (model, tea.Cmd) {
+	m.client.SetAccessToken(msg.accessToken)
+	m.client.SetUserID(msg.userID)
+	m.client.Auth.SaveSession() // Ignore error - not critical
+	m.login = loginState{seq: m.login.seq + 1}
+	m.currentView = LibraryView
+	m.loading = true
+	return m, tea.Batch(
+		loadLibraries(m.client),
+		createProgressUpdateCmd(),
+	)
+}
+
+// handleSessionExpired shows the login screen after the server rejected the session
+// and the client's re-auth hook could not restore it.
+func (m model) handleSessionExpired() (model, tea.Cmd) {
+	m.client.SetAccessToken("")
+	m.client.SetUserID("")
+	m, cmd := m.restartBrowsing()
+	m.login.status = "Your session has expired, please log in again."
+	return m, cmd
+}
+
+// regenerateQuickConnect drops the current code and requests a new one.
+func (m model) regenerateQuickConnect() (model, tea.Cmd) {
+	m.login.seq++
+	m.login.quickConnect = nil
+	m.login.polling = false
+	m.login.cancelled = false
+	m.login.usePassword = false
+	m.login.err = nil
+	m.login.status = ""
+	return m, startQuickConnect(m.client, m.login.seq)
+}
+
+// --- Key handling -----------------------------------------------------------
+
+func (m model) handleLoginKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if m.login.usePassword {
+		return m.handlePasswordFormKey(msg)
+	}
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "r":
+		return m.regenerateQuickConnect()
+	case "P":
+		m.login.seq++
+		m.login.quickConnect = nil
+		m.login.polling = false
+		return m.openProfilePicker()
+	case "p":
+		m.login.seq++
+		m.login.quickConnect = nil
+		m.login.polling = false
+		m.login.usePassword = true
+		m.login.err = nil
+		m.login.status = ""
+	case "esc":
+		m.login.seq++
+		m.login.quickConnect = nil
+		m.login.polling = false
+		m.login.cancelled = true
+		m.login.err = nil
+		m.login.status = "Login cancelled. Press 'r' for a new code or 'q' to quit."
+	}
+	return m, nil
+}
+
+func (m model) handlePasswordFormKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	if m.login.submitting {
+		return m, nil
+	}
+
+	field := &m.login.username
+	if m.login.passwordFocus {
+		field = &m.login.password
+	}
+
+	switch msg.Type {
+	case tea.KeyEsc:
+		if m.client.GetConfig().AuthMethod == jellyfin.AuthMethodPassword {
+			return m, tea.Quit
+		}
+		m.login.password = ""
+		return m.regenerateQuickConnect()
+	case tea.KeyTab, tea.KeyShiftTab, tea.KeyUp, tea.KeyDown:
+		m.login.passwordFocus = !m.login.passwordFocus
+	case tea.KeyEnter:
+		if !m.login.passwordFocus {
+			m.login.passwordFocus = true
+			return m, nil
+		}
+		if m.login.username == "" {
+			m.login.err = fmt.Errorf("username cannot be empty")
+			m.login.passwordFocus = false
+			return m, nil
+		}
+		m.login.submitting = true
+		m.login.err = nil
+		m.login.status = "Signing in..."
+		return m, passwordLogin(m.client, m.login.username, m.login.password)
+	case tea.KeyBackspace:
+		if r := []rune(*field); len(r) > 0 {
+			*field = string(r[:len(r)-1])
+		}
+	case tea.KeyCtrlU:
+		*field = ""
+	case tea.KeySpace:
+		*field += " "
+	case tea.KeyRunes:
+		*field += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// --- Rendering --------------------------------------------------------------
+
+// bigDigits is a small block font used to display the Quick Connect code.
+var bigDigits = map[rune][5]string{
+	'0': {"███", "█ █", "█ █", "█ █", "███"},
+	'1': {" █ ", "██ ", " █ ", " █ ", "███"},
+	'2': {"███", "  █", "███", "█  ", "███"},
+	'3': {"███", "  █", "███", "  █", "███"},
+	'4': {"█ █", "█ █", "███", "  █", "  █"},
+	'5': {"███", "█  ", "███", "  █", "███"},
+	'6': {"███", "█  ", "███", "█ █", "███"},
+	'7': {"███", "  █", "  █", "  █", "  █"},
+	'8': {"███", "█ █", "███", "█ █", "███"},
+	'9': {"███", "█ █", "███", "  █", "███"},
+}
+
+var (
+	loginBoxStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#bb9af7")).
+			Padding(1, 4)
+
+	loginCodeStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9ece6a")).
+			Bold(true)
+
+	loginErrorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#f7768e"))
+)
+
+// renderBigCode renders a Quick Connect code with the block font, falling back
+// to plain text for codes containing characters the font doesn't know.
+func renderBigCode(code string) string {
+	rows := make([]string, 5)
+	for _, r := range code {
+		glyph, ok := bigDigits[r]
+		if !ok {
+			return loginCodeStyle.Render(code)
+		}
+		for i := range rows {
+			rows[i] += glyph[i] + "  "
+		}
+	}
+	for i := range rows {
+		rows[i] = strings.TrimRight(rows[i], " ")
+	}
+	return loginCodeStyle.Render(strings.Join(rows, "\n"))
+}
+
+func (m model) renderLogin() string {
+	var b strings.Builder
+
+	b.WriteString(headerTitleStyle.Render("󰚯 JTUI — Sign in"))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(m.client.GetConfig().ServerURL))
+	b.WriteString("\n\n")
+
+	var help string
+	if m.login.usePassword {
+		help = m.renderPasswordForm(&b)
+	} else {
+		help = m.renderQuickConnect(&b)
+	}
+
+	if m.login.status != "" {
+		b.WriteString("\n\n")
+		b.WriteString(infoStyle.Render(m.login.status))
+	}
+	if m.login.err != nil {
+		b.WriteString("\n\n")
+		b.WriteString(loginErrorStyle.Render(fmt.Sprintf("Error: %v", m.login.err)))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(dimStyle.Render(help))
+
+	box := loginBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+func (m model) renderQuickConnect(b *strings.Builder) string {
+	help := "r new code • p password login • P profiles • esc cancel • q quit"
+	qc := m.login.quickConnect
+	if qc == nil {
+		if !m.login.cancelled && m.login.err == nil {
+			b.WriteString(infoStyle.Render("Requesting a Quick Connect code..."))
+		}
+		return help
+	}
+
+	b.WriteString(infoStyle.Render("Enter this code in another Jellyfin app"))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("(Settings → Quick Connect):"))
+	b.WriteString("\n\n")
+	b.WriteString(renderBigCode(qc.Code))
+	b.WriteString("\n\n")
+	b.WriteString(loginCodeStyle.Render(qc.Code))
+	b.WriteString("\n\n")
+
+	remaining := qc.ExpiresAt.Sub(m.login.now)
+	if remaining > 0 {
+		b.WriteString(infoStyle.Render(fmt.Sprintf("Waiting for approval • expires in %s", formatSeconds(remaining.Seconds()))))
+	}
+	return help
+}
+
+func (m model) renderPasswordForm(b *strings.Builder) string {
+	field := func(label, value string, focused bool) string {
+		cursor := " "
+		if focused {
+			cursor = "█"
+		}
+		line := fmt.Sprintf("%-10s %s%s", label, value, cursor)
+		if focused {
+			return selectedStyle.Render(line)
+		}
+		return itemStyle.Render(line)
+	}
+
+	b.WriteString(field("Username:", m.login.username, !m.login.passwordFocus))
+	b.WriteString("\n")
+	b.WriteString(field("Password:", strings.Repeat("•", len([]rune(m.login.password))), m.login.passwordFocus))
+
+	if m.client.GetConfig().AuthMethod == jellyfin.AuthMethodPassword {
+		return "tab switch field • enter sign in • esc quit"
+	}
+	return "tab switch field • enter sign in • esc back to Quick Connect"
+}
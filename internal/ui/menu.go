@@ -16,7 +16,7 @@ var globalProgram *tea.Program
 // globalImageArea tracks the current Kitty image position for cleanup.
 var globalImageArea *imageArea
 
-// Menu launches the TUI with no pre-authenticated client.
+// Menu launches the TUI, connecting to the server from the configuration.
 func Menu() {
 	setupCleanupHandlers()
 	go cleanupYaziCache()
@@ -30,7 +30,8 @@ func Menu() {
 	CleanupMpvProcesses()
 }
 
-// MenuWithClient launches the TUI with a pre-authenticated client.
+// MenuWithClient launches the TUI with a connected client. If the client still
+// needs to authenticate, the login screen is shown before the libraries.
 func MenuWithClient(client *jellyfin.Client) {
 	setupCleanupHandlers()
 	go cleanupYaziCache()
@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"context"
+	"time"
+
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/Banh-Canh/jtui/pkg/jellyfin"
@@ -14,6 +17,15 @@ const (
 	FolderView
 	ItemView
 	SearchView
+	LoginView
+	ProfileView
+	FilterView
+	PlaylistView
+	GuideView
+	VersionView
+	ChapterView
+	SeekView
+	KeysView
 )
 
 // FilterType represents an item filter mode.
@@ -45,8 +57,9 @@ const (
 
 // pathItem represents a breadcrumb entry in the navigation path.
 type pathItem struct {
-	name string
-	id   string
+	name     string
+	id       string
+	itemType string // Jellyfin type of the folder, empty for virtual folders
 }
 
 // imageArea tracks the on-screen location of a rendered terminal image so it
@@ -85,6 +98,9 @@ type model struct {
 	// Cached track info (updated with progress tick, not in View())
 	cachedSubtitleTrack string
 	cachedAudioTrack    string
+	cachedVolume        float64 // percent, 0 if unknown
+	cachedSpeed         float64 // 0 if unknown
+	cachedMuted         bool
 	// Cached download status (updated when details change, not every render)
 	cachedDownloaded    bool
 	cachedDownloadSize  int64
@@ -95,12 +111,50 @@ type model struct {
 	filter              FilterType
 	downloadedIDCache   map[string]bool // item IDs known to be downloaded (sidecar + video exists)
 	downloadedParentIDs map[string]bool // folder IDs/names that contain downloaded items
-	downloadedFilenames map[string]bool // base filenames of downloaded .mkv files (lowercased)
+	downloadedFilenames map[string]bool // base filenames of downloaded files (lowercased)
+	// Criteria picked in the filter popup (FilterView), see filter.go
+	itemFilter  itemFilter
+	filterPopup filterPopupState
+	// "Add to playlist" picker (PlaylistView), see playlists.go
+	playlistPicker playlistPickerState
+	// Live TV guide (GuideView), see livetv.go
+	guide guideState
+	// Version picker (VersionView), see versions.go
+	versionPicker versionPickerState
+	// Chapter picker (ChapterView), see chapters.go
+	chapterPicker chapterPickerState
+	// Timestamp to seek to (SeekView), see controls.go
+	seekPrompt seekPromptState
+	// Key reference (KeysView), see keys.go
+	keysPage keysPageState
+	// Media segments of the playing item, see segments.go
+	segments segmentState
+	// Countdown to the next episode, see binge.go
+	binge bingeState
+	// Entries of the play queue ready so far, see queue.go
+	queueFill queueFilledMsg
+	// Sort mode of each library, see sort.go
+	sortModes map[string]SortMode
 	// Debounce & staleness tracking for detail loading
 	detailSeq       uint64 // monotonic counter; incremented on every cursor move
 	pendingDetailID string // item ID waiting to be loaded after debounce
 	// Download status cache for item list rendering (avoids os.Stat in View)
 	itemDownloadCache map[string]bool // itemID -> downloaded?  built lazily
+	// Cancellation of requests that go stale when the cursor or view changes
+	detailCtx    context.Context // shared by the detail load and thumbnail fetch of the current item
+	cancelDetail context.CancelFunc
+	cancelSearch context.CancelFunc
+	cancelList   context.CancelFunc
+	// Paged folder listing, see paging.go
+	pagedParentID string // folder whose items are loaded page by page, empty for other lists
+	totalItems    int    // number of items in that folder on the server
+	loadingMore   bool   // a further page is being fetched
+	pageFailed    bool   // the last further page failed to load, it is retried as the cursor moves
+	listCtx       context.Context
+	// Login screen state (LoginView)
+	login loginState
+	// Server profile picker state (ProfileView)
+	profiles profilePickerState
 }
 
 // --- Messages ---------------------------------------------------------------
@@ -117,6 +171,62 @@ type itemsLoadedMsg struct {
 	items []jellyfin.Item
 }
 
+// itemsPageLoadedMsg carries one page of a folder listing.
+type itemsPageLoadedMsg struct {
+	parentID string
+	page     *jellyfin.ItemsPage
+}
+
+// itemsPageFailedMsg reports that a further page of a folder failed to load. Unlike
+// the first page, it leaves the loaded items on screen.
+type itemsPageFailedMsg struct {
+	parentID   string
+	startIndex int
+	err        error
+}
+
+type filterOptionsLoadedMsg struct {
+	options *jellyfin.FilterOptions
+	err     error
+}
+
+type playlistsLoadedMsg struct {
+	playlists []jellyfin.Item
+	err       error
+}
+
+// playlistEditedMsg reports an edit made in the playlist picker. The picker stays open
+// after renaming or deleting a playlist.
+type playlistEditedMsg struct {
+	message string
+	stay    bool
+	err     error
+}
+
+// playlistEntryMovedMsg reports that an entry moved to index in its playlist, or was
+// removed if index is -1.
+type playlistEntryMovedMsg struct {
+	playlistID string
+	entryID    string
+	index      int
+}
+
+// guideLoadedMsg carries the content of a tab of the live TV guide.
+type guideLoadedMsg struct {
+	tab        guideTab
+	at         time.Time
+	entries    []jellyfin.GuideEntry
+	recordings []jellyfin.Item
+	timers     []jellyfin.Timer
+	err        error
+}
+
+// timerUpdatedMsg reports that a recording was scheduled or cancelled.
+type timerUpdatedMsg struct {
+	message string
+	err     error
+}
+
 type itemDetailsLoadedMsg struct {
 	details *jellyfin.DetailedItem
 	seq     uint64 // sequence number to detect stale responses
@@ -147,6 +257,16 @@ type watchStatusUpdatedMsg struct {
 	watched bool
 }
 
+type favoriteUpdatedMsg struct {
+	itemID   string
+	favorite bool
+}
+
+// thumbnailFetchedMsg reports that the image of an item is in the local cache.
+type thumbnailFetchedMsg struct {
+	itemID string
+}
+
 type thumbnailLoadedMsg struct {
 	itemID    string
 	cacheKey  string
@@ -159,12 +279,58 @@ type playbackProgressMsg struct {
 	isPlaying     bool
 	subtitleTrack string
 	audioTrack    string
+	volume        float64
+	speed         float64
+	muted         bool
 }
 
 type playbackStoppedMsg struct{}
 
+// queueFilledMsg reports how many entries of a play queue are ready to play. A total
+// of 0 reports that the queue ended.
+type queueFilledMsg struct {
+	queue  *queueState
+	ready  int
+	failed int
+	total  int
+}
+
+// queueTrackChangedMsg reports the track of the play queue that mpv is playing.
+type queueTrackChangedMsg struct {
+	item *jellyfin.DetailedItem
+}
+
+// segmentsLoadedMsg carries the media segments of an item that started playing.
+type segmentsLoadedMsg struct {
+	itemID   string
+	segments []jellyfin.MediaSegment
+}
+
+// nextEpisodeLoadedMsg carries the episode after the playing one.
+type nextEpisodeLoadedMsg struct {
+	itemID string
+	next   *jellyfin.DetailedItem
+}
+
+// liveStreamStartedMsg reports that mpv plays the live stream of a channel.
+type liveStreamStartedMsg struct {
+	item *jellyfin.DetailedItem
+}
+
 type videoCompletedMsg struct {
 	itemID string
+	queued bool // the item was an entry of a play queue
+}
+
+// bingeNextMsg announces the episode to play after a finished one in binge mode.
+type bingeNextMsg struct {
+	itemID string
+	next   *jellyfin.DetailedItem
+}
+
+// bingeTickMsg counts down to the next episode in binge mode.
+type bingeTickMsg struct {
+	seq uint64
 }
 
 type stopPlaybackMsg struct{}
@@ -181,6 +347,52 @@ type downloadQueueUpdateMsg struct {
 	status jellyfin.QueueStatus
 }
 
+type quickConnectStartedMsg struct {
+	data *jellyfin.QuickConnectData
+	seq  uint64
+	err  error
+}
+
+type quickConnectPolledMsg struct {
+	seq         uint64
+	accessToken string // empty while the code is pending
+	userID      string
+	err         error
+}
+
+// loginTickMsg drives the Quick Connect countdown and polling.
+type loginTickMsg struct {
+	seq uint64
+	now time.Time
+}
+
+type passwordLoginFailedMsg struct {
+	err error
+}
+
+type loginSucceededMsg struct {
+	accessToken string
+	userID      string
+}
+
+// connectivityTickMsg triggers the next periodic probe of the connectivity monitor.
+type connectivityTickMsg struct {
+	client *jellyfin.Client
+}
+
+type connectivityCheckedMsg struct {
+	client  *jellyfin.Client
+	changed bool                  // the client switched between online and offline mode
+	online  *jellyfin.OnlineProbe // set when an offline client can go back online
+	cause   error                 // request error that triggered the check, nil for periodic probes
+}
+
+type profileSwitchedMsg struct {
+	client *jellyfin.Client
+	name   string
+	err    error
+}
+
 // --- Styles -----------------------------------------------------------------
 
 var (
@@ -206,6 +418,10 @@ var (
 				Foreground(lipgloss.Color("#f7768e")).
 				Bold(true)
 
+	headerDegradedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#e0af68")).
+				Bold(true)
+
 	headerDividerStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#3b4261"))
 
@@ -229,4 +445,20 @@ var (
 
 	infoStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#c0caf5"))
+
+	// Progress bar styles
+	progressStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#7D56F4")).
+			Padding(0, 1)
+
+	progressTitleStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA")).
+				Bold(true)
+
+	progressTimeStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#AAAAAA"))
+
+	progressTrackStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#88C999"))
 )
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// trackLabel is the list entry of a track: its number, title and duration.
+func trackLabel(d jellyfin.DetailedItem) string {
+	label := d.GetName()
+	if d.IndexNumber > 0 {
+		label = fmt.Sprintf("%02d. %s", d.IndexNumber, label)
+	}
+	if d.RunTimeTicks > 0 {
+		label += "  " + formatSeconds(float64(d.RunTimeTicks)/10000000.0)
+	}
+	return label
+}
+
+// playAlbum plays all tracks of an album in order.
+func playAlbum(client *jellyfin.Client, albumID string) tea.Cmd {
+	return func() tea.Msg {
+		tracks, err := client.Items.GetAlbumTracks(albumID)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to get album tracks: %w", err)}
+		}
+		return startQueue(client, tracks, 0)
+	}
+}
+
+// shuffleArtist plays all tracks of an artist in random order.
+func shuffleArtist(client *jellyfin.Client, artistID string) tea.Cmd {
+	return func() tea.Msg {
+		tracks, err := client.Items.GetArtistTracks(artistID, true)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to get artist tracks: %w", err)}
+		}
+		return startQueue(client, tracks, 0)
+	}
+}
+
+// downloadAlbum adds all tracks of an album to the download queue.
+func downloadAlbum(client *jellyfin.Client, albumID, albumName string) tea.Cmd {
+	return func() tea.Msg {
+		count, err := client.Download.EnqueueAlbum(albumID)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to enqueue album: %w", err)}
+		}
+		if count == 0 {
+			return successMsg{fmt.Sprintf("All tracks of %s already downloaded", albumName)}
+		}
+		return successMsg{fmt.Sprintf("Queued %d tracks of %s", count, albumName)}
+	}
+}
+
+// playTrackList plays the tracks of the current list as a queue, starting with the
+// one under the cursor, so that an album plays on from the selected track.
+func (m model) playTrackList() (model, tea.Cmd) {
+	var tracks []jellyfin.DetailedItem
+	start := 0
+	for i, item := range m.items {
+		d, ok := detailedOf(item)
+		if !ok || !d.IsAudio() {
+			continue
+		}
+		if i == m.cursor {
+			start = len(tracks)
+		}
+		tracks = append(tracks, d)
+	}
+	if len(tracks) == 0 {
+		return m, nil
+	}
+	m.currentPlayingItem = &tracks[start]
+	return m, tea.Batch(playQueue(m.client, tracks, start), createDelayedProgressUpdateCmd())
+}
@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// Folder listings are fetched one page at a time. The first page replaces the list
+// like any other load; further pages are appended once the cursor gets within one
+// viewport of the last loaded item.
+
+// listContext cancels the in-flight page requests of the previous folder and
+// returns the context for the pages of the next one.
+func (m *model) listContext() context.Context {
+	m.cancelListRequests()
+	m.listCtx, m.cancelList = context.WithCancel(context.Background())
+	return m.listCtx
+}
+
+func (m *model) cancelListRequests() {
+	if m.cancelList != nil {
+		m.cancelList()
+		m.cancelList = nil
+	}
+	m.loadingMore = false
+	m.pageFailed = false
+}
+
+// resetPaging marks the current list as fully loaded. Called whenever a list that is
+// not a paged folder listing replaces the items.
+func (m *model) resetPaging() {
+	m.pagedParentID = ""
+	m.totalItems = 0
+	m.loadingMore = false
+	m.pageFailed = false
+}
+
+// hasMoreItems reports whether the current folder has items that are not loaded yet.
+func (m model) hasMoreItems() bool {
+	return m.pagedParentID != "" && len(m.allItems) < m.totalItems
+}
+
+func (m model) handleItemsPageLoaded(msg itemsPageLoadedMsg) (model, tea.Cmd) {
+	page := msg.page
+	if page.StartIndex == 0 {
+		// Downloads come in one piece, unsorted
+		if m.client.IsOfflineMode() || strings.HasPrefix(msg.parentID, "offline-") {
+			return m.replaceItems(page.Items, "", 0)
+		}
+		return loadMoreIfNeeded(m.replaceItems(page.Items, msg.parentID, page.TotalCount))
+	}
+
+	// Drop pages of a folder the user has left or that do not follow the loaded items
+	if msg.parentID != m.pagedParentID || page.StartIndex != len(m.allItems) {
+		return m, nil
+	}
+	m.loadingMore = false
+	m.pageFailed = false
+	m.totalItems = page.TotalCount
+	if len(page.Items) == 0 {
+		m.totalItems = len(m.allItems) // The folder shrank since the first page
+		return m, nil
+	}
+
+	// Keep the selection where it is while the list grows
+	var selectedID string
+	if m.cursor < len(m.items) {
+		selectedID = m.items[m.cursor].GetID()
+	}
+	m.allItems = append(m.allItems, page.Items...)
+	m.sortItems()
+	m.applyFilter()
+	for i, item := range m.items {
+		if item.GetID() == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+	m.clampCursor()
+	m.updateViewport()
+	m.refreshItemDownloadCache()
+
+	// The filter may have hidden the whole page
+	return loadMoreIfNeeded(m, nil)
+}
+
+// handleItemsPageFailed keeps the loaded items and lets the next cursor move fetch the
+// page again. A lost connection is noticed by the connectivity monitor, an expired
+// session is handled like for any other request.
+func (m model) handleItemsPageFailed(msg itemsPageFailedMsg) (model, tea.Cmd) {
+	if msg.parentID != m.pagedParentID || msg.startIndex != len(m.allItems) {
+		return m, nil
+	}
+	m.loadingMore = false
+	m.pageFailed = true
+	if errors.Is(msg.err, jellyfin.ErrUnauthorized) && !m.client.IsOfflineMode() {
+		return m.handleSessionExpired()
+	}
+	return m, nil
+}
+
+// loadMoreIfNeeded wraps a navigation handler and fetches the next page of the current
+// folder when the cursor has come within one viewport of the end of the loaded items.
+func loadMoreIfNeeded(m model, cmd tea.Cmd) (model, tea.Cmd) {
+	if !m.hasMoreItems() || m.loadingMore || m.loading || m.listCtx == nil {
+		return m, cmd
+	}
+	if m.cursor < len(m.items)-m.viewport {
+		return m, cmd
+	}
+	m.loadingMore = true
+	return m, tea.Batch(cmd, loadItemsPage(m.listCtx, m.client, m.pagedParentID, m.folderQuery(len(m.allItems))))
+}
+
+// folderQuery builds the query of the page of the current folder starting at
+// startIndex, sorted and filtered by the server. Artists are not the parents of their
+// albums, so their albums are queried by artist.
+func (m model) folderQuery(startIndex int) *jellyfin.ItemsQuery {
+	parent := m.currentPath[len(m.currentPath)-1]
+	var q *jellyfin.ItemsQuery
+	switch parent.itemType {
+	case jellyfin.ItemTypeMusicArtist:
+		q = jellyfin.NewArtistAlbumsQuery(parent.id)
+	case jellyfin.ItemTypeMusicAlbum:
+		q = jellyfin.NewAlbumTracksQuery(parent.id)
+	default:
+		q = jellyfin.NewFolderQuery(parent.id, true)
+	}
+	q.WithPage(startIndex, jellyfin.DefaultPageSize)
+	m.currentSort().apply(q)
+	if filterServerSide(parent) {
+		m.itemFilter.apply(q)
+	}
+	return q
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// Seasons, series and collections play as a queue like albums do: Space plays all of
+// their videos in order and S shuffles them, in a single mpv process.
+
+// playAll plays the entries of a season, a series, a collection, an album, an artist or
+// a playlist as a queue. Seasons and series played in order start with their first
+// unwatched episode; the watched ones are still in the queue before it.
+func playAll(client *jellyfin.Client, item jellyfin.Item, seriesID string, shuffled bool) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := queueEntries(client, item, seriesID)
+		if err != nil {
+			return errMsg{err}
+		}
+		start := 0
+		switch itemType := itemTypeOf(item); {
+		case shuffled:
+			rand.Shuffle(len(entries), func(i, j int) {
+				entries[i], entries[j] = entries[j], entries[i]
+			})
+		case itemType == jellyfin.ItemTypeSeason || itemType == jellyfin.ItemTypeSeries:
+			start = max(slices.IndexFunc(entries, func(e jellyfin.DetailedItem) bool { return !e.IsWatched() }), 0)
+		}
+		return startQueue(client, entries, start)
+	}
+}
+
+// queueEntries returns the entries of an item that plays as a queue, in playback order.
+// Downloaded series list their episodes as they are browsed.
+func queueEntries(client *jellyfin.Client, item jellyfin.Item, seriesID string) ([]jellyfin.DetailedItem, error) {
+	id := item.GetID()
+	switch itemTypeOf(item) {
+	case jellyfin.ItemTypeSeason:
+		episodes, err := client.Items.GetEpisodes(seriesID, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get season episodes: %w", err)
+		}
+		return episodes, nil
+	case jellyfin.ItemTypeSeries:
+		if strings.HasPrefix(id, "offline-") {
+			return offlineEntries(client, id)
+		}
+		episodes, err := client.Items.GetAllEpisodes(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get series episodes: %w", err)
+		}
+		return episodes, nil
+	case jellyfin.ItemTypeBoxSet:
+		videos, err := client.Items.GetVideos(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get collection videos: %w", err)
+		}
+		return videos, nil
+	case jellyfin.ItemTypeMusicAlbum:
+		tracks, err := client.Items.GetAlbumTracks(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get album tracks: %w", err)
+		}
+		return tracks, nil
+	case jellyfin.ItemTypeMusicArtist:
+		tracks, err := client.Items.GetArtistTracks(id, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get artist tracks: %w", err)
+		}
+		return tracks, nil
+	case jellyfin.ItemTypePlaylist:
+		entries, err := client.Playlists.GetEntries(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get playlist entries: %w", err)
+		}
+		return entries, nil
+	}
+	return nil, fmt.Errorf("%s does not play as a queue", item.GetName())
+}
+
+// offlineEntries returns the downloaded episodes of a series.
+func offlineEntries(client *jellyfin.Client, seriesID string) ([]jellyfin.DetailedItem, error) {
+	items, err := client.Items.Get(seriesID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offline episodes: %w", err)
+	}
+	var entries []jellyfin.DetailedItem
+	for _, item := range items {
+		if d, ok := detailedOf(item); ok {
+			entries = append(entries, d)
+		}
+	}
+	return entries, nil
+}
+
+// playsAll reports whether Space plays all the videos of an item.
+func playsAll(itemType string) bool {
+	switch itemType {
+	case jellyfin.ItemTypeSeason, jellyfin.ItemTypeSeries, jellyfin.ItemTypeBoxSet:
+		return true
+	}
+	return false
+}
+
+// seriesIDOf returns the series of a season, from its details or else from the path.
+func (m model) seriesIDOf(item jellyfin.Item) string {
+	if d, ok := detailedOf(item); ok && d.SeriesID != "" {
+		return d.SeriesID
+	}
+	return m.parentSeriesID()
+}
+
+// handleShuffle plays the season, series, collection, album, artist or playlist under
+// the cursor in random order.
+func (m model) handleShuffle() (model, tea.Cmd) {
+	if len(m.items) == 0 || m.cursor >= len(m.items) {
+		return m, nil
+	}
+	item := m.items[m.cursor]
+	itemType := itemTypeOf(item)
+	music := []string{jellyfin.ItemTypeMusicAlbum, jellyfin.ItemTypeMusicArtist, jellyfin.ItemTypePlaylist}
+	if !playsAll(itemType) && !slices.Contains(music, itemType) {
+		return m, nil
+	}
+	return m, playAll(m.client, item, m.seriesIDOf(item), true)
+}
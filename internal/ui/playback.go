@@ -1,13 +1,16 @@
 package ui
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"net"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -23,17 +26,16 @@ var (
 
 // playItem starts mpv playback for a media item, optionally resuming from startPositionTicks.
 func playItem(client *jellyfin.Client, itemID string, startPositionTicks int64) tea.Cmd {
+	return playItemSource(client, itemID, "", startPositionTicks)
+}
+
+// playItemSource is like playItem but plays the given version of the item, the
+// server's choice if mediaSourceID is empty.
+func playItemSource(client *jellyfin.Client, itemID, mediaSourceID string, startPositionTicks int64) tea.Cmd {
 	return func() tea.Msg {
-		// Close any existing jtui-launched videos before starting a new one
-		mpvMu.Lock()
-		hasRunning := len(runningMpvProcesses) > 0
-		mpvMu.Unlock()
-		if hasRunning {
-			CleanupMpvProcesses()
-		}
+		closeRunningMpv()
 
-		var streamURL string
-		var isLocal bool
+		var stream *jellyfin.StreamInfo
 
 		detailedItem, err := client.Items.GetDetails(itemID)
 		if err != nil {
@@ -49,96 +51,192 @@ func playItem(client *jellyfin.Client, itemID string, startPositionTicks int64)
 			if err != nil {
 				return errMsg{fmt.Errorf("failed to get offline content: %w", err)}
 			}
-			streamURL = filePath
-			isLocal = true
+			stream = &jellyfin.StreamInfo{
+				ItemID:        itemID,
+				URL:           filePath,
+				IsLocal:       true,
+				PlayMethod:    jellyfin.PlayMethodDirectPlay,
+				SubtitleFiles: client.Download.LocalSubtitles(filePath),
+			}
 		} else {
-			streamURL, isLocal = client.Playback.GetPlaybackURL(itemID, detailedItem)
+			stream, err = client.Playback.GetPlaybackStream(itemID, detailedItem, jellyfin.StreamOptions{MediaSourceID: mediaSourceID})
+			if err != nil {
+				return errMsg{err}
+			}
 		}
 
 		// Prepare mpv command with JSON IPC
 		args := []string{"--input-ipc-server=" + mpvSocketPath, "--title=jtui-player"}
+		args = append(args, languageArgs(client.Playback.GetLanguagePreferences())...)
 		if startPositionTicks > 0 {
 			startSeconds := float64(startPositionTicks) / 10000000.0
 			args = append(args, fmt.Sprintf("--start=%.2f", startSeconds))
 		}
-		args = append(args, streamURL)
+		for _, subtitle := range stream.SubtitleFiles {
+			args = append(args, "--sub-file="+subtitle)
+		}
+		args = append(args, stream.URL)
 		cmd := exec.Command("mpv", args...)
+		registerMpvProcess(cmd)
 
-		mpvMu.Lock()
-		runningMpvProcesses = append(runningMpvProcesses, cmd)
-		mpvMu.Unlock()
-
-		go trackPlayback(client, cmd, itemID, isLocal)
+		go trackPlayback(client, cmd, stream)
 
 		return nil
 	}
 }
 
-// trackPlayback runs in a goroutine to monitor mpv and report progress.
-func trackPlayback(client *jellyfin.Client, cmd *exec.Cmd, itemID string, isLocal bool) {
-	if !isLocal {
-		client.Playback.ReportStart(itemID)
+// languageArgs returns the mpv options selecting the audio and subtitle tracks in the
+// preferred languages, following the subtitle mode of the user on the server:
+//   - None turns subtitles off unless a subtitle language is configured.
+//   - OnlyForced only selects forced subtitles, whatever their language.
+//   - Smart leaves subtitles off when the audio is in a preferred subtitle language.
+//   - Default and Always select the preferred languages, and mpv falls back to the
+//     tracks flagged default or forced.
+func languageArgs(prefs jellyfin.LanguagePreferences) []string {
+	var args []string
+	if len(prefs.Audio) > 0 {
+		args = append(args, "--alang="+strings.Join(prefs.Audio, ","))
 	}
+	switch {
+	case prefs.SubtitleMode == jellyfin.SubtitleModeOnlyForced:
+		return append(args, "--subs-fallback=no", "--subs-fallback-forced=always")
+	case len(prefs.Subtitle) > 0:
+		args = append(args, "--slang="+strings.Join(prefs.Subtitle, ","))
+	case prefs.SubtitleMode == jellyfin.SubtitleModeNone:
+		args = append(args, "--sid=no")
+	}
+	if prefs.SubtitleMode == jellyfin.SubtitleModeSmart {
+		args = append(args, "--subs-with-matching-audio=no")
+	}
+	return args
+}
 
-	done := make(chan bool)
-
-	if !isLocal {
-		go func() {
-			ticker := time.NewTicker(5 * time.Second)
-			defer ticker.Stop()
-			for {
-				select {
-				case <-done:
-					return
-				case <-ticker.C:
-					if position := getMpvFloatProperty("time-pos"); position > 0 {
-						client.Playback.ReportProgress(itemID, int64(position*10000000))
-					}
-				}
-			}
-		}()
+// closeRunningMpv closes any existing jtui-launched player before starting a new one.
+func closeRunningMpv() {
+	mpvMu.Lock()
+	hasRunning := len(runningMpvProcesses) > 0
+	mpvMu.Unlock()
+	if hasRunning {
+		CleanupMpvProcesses()
 	}
+}
 
-	runErr := cmd.Run()
-	close(done)
+func registerMpvProcess(cmd *exec.Cmd) {
+	mpvMu.Lock()
+	runningMpvProcesses = append(runningMpvProcesses, cmd)
+	mpvMu.Unlock()
+}
 
-	// Remove from tracking list
+// unregisterMpvProcess removes an exited player from the tracking list. It returns
+// false if the player was no longer tracked, i.e. jtui closed it.
+func unregisterMpvProcess(cmd *exec.Cmd) bool {
 	mpvMu.Lock()
+	defer mpvMu.Unlock()
 	for i, p := range runningMpvProcesses {
 		if p == cmd {
 			runningMpvProcesses = append(runningMpvProcesses[:i], runningMpvProcesses[i+1:]...)
-			break
+			return true
 		}
 	}
-	mpvMu.Unlock()
+	return false
+}
 
-	if runErr != nil {
+// mpvProcessTracked reports whether a player is still tracked, i.e. jtui did not close it.
+func mpvProcessTracked(cmd *exec.Cmd) bool {
+	mpvMu.Lock()
+	defer mpvMu.Unlock()
+	return slices.Contains(runningMpvProcesses, cmd)
+}
+
+// trackPlayback runs in a goroutine to monitor mpv and report progress.
+func trackPlayback(client *jellyfin.Client, cmd *exec.Cmd, stream *jellyfin.StreamInfo) {
+	itemID, isLocal := stream.ItemID, stream.IsLocal
+	if !isLocal {
+		client.Playback.ReportStart(stream)
+	}
+	done := make(chan bool)
+	var lastPositionTicks atomic.Int64
+
+	// The segments are fetched while mpv starts, the credits are unknown until then
+	var credits atomic.Int64
+	go func() {
+		credits.Store(outroStart(fetchSegments(client, itemID)))
+	}()
+
+	// The position is polled every second so that stopping within the credits is
+	// noticed, and reported every 5 seconds
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for polls := 1; ; polls++ {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if position := getMpvFloatProperty("time-pos"); position > 0 {
+					lastPositionTicks.Store(int64(position * 10000000))
+					if !isLocal && polls%5 == 0 {
+						client.Playback.ReportProgress(stream, lastPositionTicks.Load())
+					}
+				}
+			}
+		}
+	}()
+
+	runErr := cmd.Run()
+	close(done)
+	// A player closed by jtui, e.g. to play the next episode, was killed: that is no failure
+	closedByUs := !unregisterMpvProcess(cmd)
+
+	stopped := false
+	defer func() {
+		// Tell the server the stream is over so that it ends its transcode, if any
+		if !isLocal && !stopped {
+			client.Playback.ReportStop(stream, lastPositionTicks.Load())
+		}
+	}()
+
+	if runErr != nil && !closedByUs {
 		if globalProgram != nil {
 			globalProgram.Send(errMsg{fmt.Errorf("mpv playback failed: %w", runErr)})
 		}
 		return
 	}
 
-	// Handle completion
-	if finalPosition := getMpvFloatProperty("time-pos"); finalPosition > 0 {
-		finalPositionTicks := int64(finalPosition * 10000000)
+	// Handle completion: the item is watched near its end, or once its credits started.
+	// A player closed by jtui is only judged by its last polled position, since the IPC
+	// socket may already belong to the next player.
+	completed := credits.Load() > 0 && lastPositionTicks.Load() >= credits.Load()
+	if finalPosition := finalMpvPosition(closedByUs); finalPosition > 0 {
+		lastPositionTicks.Store(int64(finalPosition * 10000000))
 		if !isLocal {
-			client.Playback.ReportProgress(itemID, finalPositionTicks)
+			client.Playback.ReportProgress(stream, lastPositionTicks.Load())
 		}
-		if finalDuration := getMpvFloatProperty("duration"); finalDuration > 0 {
-			if (finalPosition/finalDuration)*100 >= 90.0 {
-				if !isLocal {
-					client.Playback.MarkWatched(itemID)
-					client.Playback.ReportStop(itemID, finalPositionTicks)
-				} else if client.IsAuthenticated() {
-					client.Playback.MarkWatched(itemID)
-				}
-				if globalProgram != nil {
-					globalProgram.Send(videoCompletedMsg{itemID: itemID})
-				}
-			}
+		if finalDuration := getMpvFloatProperty("duration"); finalDuration > 0 && (finalPosition/finalDuration)*100 >= 90.0 {
+			completed = true
 		}
 	}
+	if completed {
+		if !isLocal {
+			client.Playback.MarkWatched(itemID)
+			client.Playback.ReportStop(stream, lastPositionTicks.Load())
+			stopped = true
+		} else if client.IsAuthenticated() {
+			client.Playback.MarkWatched(itemID)
+		}
+		if globalProgram != nil {
+			globalProgram.Send(videoCompletedMsg{itemID: itemID})
+		}
+	}
+}
+
+// finalMpvPosition returns the position mpv stopped at, 0 if it can't be read or the
+// player was closed by jtui.
+func finalMpvPosition(closedByUs bool) float64 {
+	if closedByUs {
+		return 0
+	}
+	return getMpvFloatProperty("time-pos")
 }
 
 // ---------------------------------------------------------------------------
@@ -270,6 +368,42 @@ func sendMpvCommand(args ...string) error {
 	return nil
 }
 
+// runMpvCommand sends a command to mpv and waits until it is carried out, so that
+// commands sent one after another apply in order.
+func runMpvCommand(args ...string) error {
+	conn, err := net.DialTimeout("unix", mpvSocketPath, time.Second)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(2 * time.Second))
+	jsonData, err := json.Marshal(map[string]interface{}{"command": args})
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write(append(jsonData, '\n')); err != nil {
+		return fmt.Errorf("failed to write to mpv socket: %w", err)
+	}
+	// Events may come before the reply
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var reply struct {
+			Error *string `json:"error"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &reply) != nil || reply.Error == nil {
+			continue
+		}
+		if *reply.Error != "success" {
+			return fmt.Errorf("mpv %s: %s", args[0], *reply.Error)
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("mpv %s: no reply", args[0])
+}
+
 // checkMpvStatus checks if mpv is running and returns current status with retry logic.
 func checkMpvStatus() (position, duration float64, isPlaying bool, subtitleTrack, audioTrack string) {
 	maxRetries := 3
@@ -300,29 +434,31 @@ func checkMpvStatus() (position, duration float64, isPlaying bool, subtitleTrack
 // Playback tea.Cmd wrappers
 // ---------------------------------------------------------------------------
 
+// readPlaybackProgress polls mpv for the progress bar.
+func readPlaybackProgress() playbackProgressMsg {
+	position, duration, isPlaying, subtitleTrack, audioTrack := checkMpvStatus()
+	msg := playbackProgressMsg{
+		position:      position,
+		duration:      duration,
+		isPlaying:     isPlaying,
+		subtitleTrack: subtitleTrack,
+		audioTrack:    audioTrack,
+	}
+	if isPlaying {
+		msg.volume, msg.speed, msg.muted = getMpvControls()
+	}
+	return msg
+}
+
 func createProgressUpdateCmd() tea.Cmd {
 	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
-		position, duration, isPlaying, subtitleTrack, audioTrack := checkMpvStatus()
-		return playbackProgressMsg{
-			position:      position,
-			duration:      duration,
-			isPlaying:     isPlaying,
-			subtitleTrack: subtitleTrack,
-			audioTrack:    audioTrack,
-		}
+		return readPlaybackProgress()
 	})
 }
 
 func createDelayedProgressUpdateCmd() tea.Cmd {
 	return tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg {
-		position, duration, isPlaying, subtitleTrack, audioTrack := checkMpvStatus()
-		return playbackProgressMsg{
-			position:      position,
-			duration:      duration,
-			isPlaying:     isPlaying,
-			subtitleTrack: subtitleTrack,
-			audioTrack:    audioTrack,
-		}
+		return readPlaybackProgress()
 	})
 }
 
@@ -0,0 +1,437 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// playlistsFolderID is the virtual folder listing the user's playlists.
+const playlistsFolderID = "virtual-playlists"
+
+// playlistEdit is the edit in progress in the playlist picker.
+type playlistEdit int
+
+const (
+	playlistEditNone   playlistEdit = iota
+	playlistEditCreate              // typing the name of a new playlist
+	playlistEditRename              // typing the new name of the selected playlist
+	playlistEditDelete              // waiting for the deletion to be confirmed
+)
+
+// playlistPickerState holds the state of the "add to playlist" picker (PlaylistView).
+// The row after the last playlist creates a new playlist.
+type playlistPickerState struct {
+	item         jellyfin.Item // item to add
+	playlists    []jellyfin.Item
+	cursor       int
+	edit         playlistEdit
+	name         string
+	busy         bool // loading the playlists or waiting for an edit
+	changed      bool // playlists were edited while the picker was open
+	err          error
+	previousView ViewType
+}
+
+func loadPlaylists(client *jellyfin.Client) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return errMsg{fmt.Errorf("client is nil")}
+		}
+		playlists, err := client.Playlists.GetAll()
+		if err != nil {
+			return errMsg{err}
+		}
+		return itemsLoadedMsg{playlists}
+	}
+}
+
+func loadPlaylistEntries(client *jellyfin.Client, playlistID string) tea.Cmd {
+	return func() tea.Msg {
+		if client == nil {
+			return errMsg{fmt.Errorf("client is nil")}
+		}
+		entries, err := client.Playlists.GetEntries(playlistID)
+		if err != nil {
+			return errMsg{err}
+		}
+		items := make([]jellyfin.Item, len(entries))
+		for i, entry := range entries {
+			items[i] = entry
+		}
+		return itemsLoadedMsg{items}
+	}
+}
+
+// playPlaylist plays all entries of a playlist in order.
+func playPlaylist(client *jellyfin.Client, playlistID string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := client.Playlists.GetEntries(playlistID)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to get playlist entries: %w", err)}
+		}
+		return startQueue(client, entries, 0)
+	}
+}
+
+// --- Picker -----------------------------------------------------------------
+
+func loadPlaylistChoices(client *jellyfin.Client) tea.Cmd {
+	return func() tea.Msg {
+		playlists, err := client.Playlists.GetAll()
+		return playlistsLoadedMsg{playlists: playlists, err: err}
+	}
+}
+
+func addToPlaylist(client *jellyfin.Client, playlist, item jellyfin.Item) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.Playlists.Add(playlist.GetID(), item.GetID()); err != nil {
+			return playlistEditedMsg{err: fmt.Errorf("failed to add to playlist: %w", err)}
+		}
+		return playlistEditedMsg{message: fmt.Sprintf("Added %s to %s", item.GetName(), playlist.GetName())}
+	}
+}
+
+func createPlaylist(client *jellyfin.Client, name string, item jellyfin.Item) tea.Cmd {
+	return func() tea.Msg {
+		if _, err := client.Playlists.Create(name, item.GetID()); err != nil {
+			return playlistEditedMsg{err: fmt.Errorf("failed to create playlist: %w", err)}
+		}
+		return playlistEditedMsg{message: fmt.Sprintf("Added %s to the new playlist %s", item.GetName(), name)}
+	}
+}
+
+func renamePlaylist(client *jellyfin.Client, playlistID, name string) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.Playlists.Rename(playlistID, name); err != nil {
+			return playlistEditedMsg{err: fmt.Errorf("failed to rename playlist: %w", err)}
+		}
+		return playlistEditedMsg{stay: true}
+	}
+}
+
+func deletePlaylist(client *jellyfin.Client, playlistID string) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.Playlists.Delete(playlistID); err != nil {
+			return playlistEditedMsg{err: fmt.Errorf("failed to delete playlist: %w", err)}
+		}
+		return playlistEditedMsg{stay: true}
+	}
+}
+
+func (m model) openPlaylistPicker() (model, tea.Cmd) {
+	if len(m.items) == 0 || m.cursor >= len(m.items) || m.client.IsOfflineMode() {
+		return m, nil
+	}
+	item := m.items[m.cursor]
+	if isVirtualFolder(item.GetID()) || strings.HasPrefix(item.GetID(), "offline-") {
+		return m, nil
+	}
+	m.playlistPicker = playlistPickerState{
+		item:         item,
+		busy:         true,
+		previousView: m.currentView,
+	}
+	m.currentView = PlaylistView
+	return m, loadPlaylistChoices(m.client)
+}
+
+func (m model) handlePlaylistsLoaded(msg playlistsLoadedMsg) (model, tea.Cmd) {
+	if m.currentView != PlaylistView {
+		return m, nil
+	}
+	p := &m.playlistPicker
+	p.busy = false
+	p.err = msg.err
+	p.playlists = msg.playlists
+	p.cursor = min(p.cursor, len(p.playlists))
+	return m, nil
+}
+
+// handlePlaylistEdited closes the picker once the item is added, or reloads the
+// playlists after a rename or deletion.
+func (m model) handlePlaylistEdited(msg playlistEditedMsg) (model, tea.Cmd) {
+	if m.currentView != PlaylistView {
+		return m, nil
+	}
+	p := &m.playlistPicker
+	if msg.err != nil {
+		p.busy = false
+		p.err = msg.err
+		return m, nil
+	}
+	p.changed = true
+	if msg.stay {
+		return m, loadPlaylistChoices(m.client)
+	}
+	m, cmd := m.closePlaylistPicker()
+	m.successMsg = msg.message
+	return m, cmd
+}
+
+// closePlaylistPicker returns to the list, reloading it if it shows playlists that
+// were edited.
+func (m model) closePlaylistPicker() (model, tea.Cmd) {
+	changed := m.playlistPicker.changed
+	m.currentView = m.playlistPicker.previousView
+	m.playlistPicker = playlistPickerState{}
+	if !changed || len(m.currentPath) == 0 {
+		return m, nil
+	}
+	parent := m.currentPath[len(m.currentPath)-1]
+	if parent.id != playlistsFolderID && parent.itemType != jellyfin.ItemTypePlaylist {
+		return m, nil
+	}
+	m.cancelRequests()
+	m.loading = true
+	cmd := m.loadCurrentFolder()
+	return m, cmd
+}
+
+func (m model) handlePlaylistKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	p := &m.playlistPicker
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+	if p.busy {
+		return m, nil
+	}
+
+	switch p.edit {
+	case playlistEditCreate, playlistEditRename:
+		return m.handlePlaylistNameKey(msg)
+	case playlistEditDelete:
+		p.edit = playlistEditNone
+		if msg.String() == "y" {
+			p.busy = true
+			p.err = nil
+			return m, deletePlaylist(m.client, p.playlists[p.cursor].GetID())
+		}
+		return m, nil
+	}
+
+	onPlaylist := p.cursor < len(p.playlists)
+	switch msg.String() {
+	case "esc", "q", "+":
+		return m.closePlaylistPicker()
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.playlists) {
+			p.cursor++
+		}
+	case "enter":
+		p.err = nil
+		if !onPlaylist {
+			p.edit = playlistEditCreate
+			p.name = ""
+			return m, nil
+		}
+		p.busy = true
+		return m, addToPlaylist(m.client, p.playlists[p.cursor], p.item)
+	case "r":
+		if onPlaylist {
+			p.err = nil
+			p.edit = playlistEditRename
+			p.name = p.playlists[p.cursor].GetName()
+		}
+	case "d":
+		if onPlaylist {
+			p.err = nil
+			p.edit = playlistEditDelete
+		}
+	}
+	return m, nil
+}
+
+// handlePlaylistNameKey edits the name of a new or renamed playlist.
+func (m model) handlePlaylistNameKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	p := &m.playlistPicker
+	switch msg.Type {
+	case tea.KeyEsc:
+		p.edit = playlistEditNone
+	case tea.KeyEnter:
+		name := strings.TrimSpace(p.name)
+		if name == "" {
+			return m, nil
+		}
+		edit := p.edit
+		p.edit = playlistEditNone
+		p.busy = true
+		if edit == playlistEditCreate {
+			return m, createPlaylist(m.client, name, p.item)
+		}
+		return m, renamePlaylist(m.client, p.playlists[p.cursor].GetID(), name)
+	case tea.KeyBackspace:
+		if len(p.name) > 0 {
+			runes := []rune(p.name)
+			p.name = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		p.name += string(msg.Runes)
+	}
+	return m, nil
+}
+
+func (m model) renderPlaylistPicker() string {
+	p := m.playlistPicker
+	var b strings.Builder
+
+	b.WriteString(headerTitleStyle.Render("Add to playlist"))
+	b.WriteString("\n")
+	if p.item != nil {
+		b.WriteString(dimStyle.Render(p.item.GetName()))
+	}
+	b.WriteString("\n\n")
+
+	if p.busy && p.playlists == nil {
+		b.WriteString(infoStyle.Render("Loading playlists..."))
+	}
+	rows := make([]string, 0, len(p.playlists)+1)
+	for _, playlist := range p.playlists {
+		rows = append(rows, playlist.GetName())
+	}
+	rows = append(rows, "+ New playlist...")
+	if p.busy && p.playlists == nil {
+		rows = nil
+	}
+	for i, row := range rows {
+		if i == p.cursor && (p.edit == playlistEditRename || p.edit == playlistEditCreate && i == len(p.playlists)) {
+			row = p.name + "█"
+		}
+		if i == p.cursor {
+			b.WriteString(selectedStyle.Render(row))
+		} else {
+			b.WriteString(itemStyle.Render(row))
+		}
+		if i < len(rows)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	if p.edit == playlistEditDelete {
+		b.WriteString("\n\n")
+		b.WriteString(loginErrorStyle.Render(fmt.Sprintf("Delete %s? (y/n)", p.playlists[p.cursor].GetName())))
+	}
+	if p.err != nil {
+		b.WriteString("\n\n")
+		b.WriteString(loginErrorStyle.Render(fmt.Sprintf("Error: %v", p.err)))
+	}
+	b.WriteString("\n\n")
+	switch p.edit {
+	case playlistEditCreate, playlistEditRename:
+		b.WriteString(dimStyle.Render("type a name • enter save • esc cancel"))
+	default:
+		b.WriteString(dimStyle.Render("↑↓/jk select • enter add • r rename • d delete • esc back"))
+	}
+
+	box := loginBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// --- Entries ----------------------------------------------------------------
+
+// currentPlaylistID returns the playlist being browsed, empty outside playlists.
+func (m model) currentPlaylistID() string {
+	if len(m.currentPath) == 0 {
+		return ""
+	}
+	parent := m.currentPath[len(m.currentPath)-1]
+	if parent.itemType != jellyfin.ItemTypePlaylist {
+		return ""
+	}
+	return parent.id
+}
+
+func removePlaylistEntry(client *jellyfin.Client, playlistID, entryID string) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.Playlists.Remove(playlistID, entryID); err != nil {
+			return errMsg{fmt.Errorf("failed to remove from playlist: %w", err)}
+		}
+		return playlistEntryMovedMsg{playlistID: playlistID, entryID: entryID, index: -1}
+	}
+}
+
+func movePlaylistEntry(client *jellyfin.Client, playlistID, entryID string, index int) tea.Cmd {
+	return func() tea.Msg {
+		if err := client.Playlists.Move(playlistID, entryID, index); err != nil {
+			return errMsg{fmt.Errorf("failed to move playlist entry: %w", err)}
+		}
+		return playlistEntryMovedMsg{playlistID: playlistID, entryID: entryID, index: index}
+	}
+}
+
+// entryIndex returns the index of a playlist entry in items, -1 if absent.
+func entryIndex(items []jellyfin.Item, entryID string) int {
+	return slices.IndexFunc(items, func(item jellyfin.Item) bool {
+		d, _ := detailedOf(item)
+		return d.PlaylistItemID == entryID
+	})
+}
+
+// selectedEntry returns the playlist entry under the cursor and its index in the playlist.
+func (m model) selectedEntry() (string, int, bool) {
+	if m.currentPlaylistID() == "" || m.cursor >= len(m.items) {
+		return "", 0, false
+	}
+	d, ok := detailedOf(m.items[m.cursor])
+	if !ok || d.PlaylistItemID == "" {
+		return "", 0, false
+	}
+	index := entryIndex(m.allItems, d.PlaylistItemID)
+	return d.PlaylistItemID, index, index >= 0
+}
+
+func (m model) handleRemovePlaylistEntry() (model, tea.Cmd) {
+	entryID, _, ok := m.selectedEntry()
+	if !ok {
+		return m, nil
+	}
+	return m, removePlaylistEntry(m.client, m.currentPlaylistID(), entryID)
+}
+
+// handleMovePlaylistEntry moves the entry under the cursor up or down by one. Entries
+// only move while the list shows the playlist order, unsorted and unfiltered.
+func (m model) handleMovePlaylistEntry(delta int) (model, tea.Cmd) {
+	entryID, index, ok := m.selectedEntry()
+	if !ok || m.currentSort() != SortDefault || len(m.items) != len(m.allItems) {
+		return m, nil
+	}
+	target := index + delta
+	if target < 0 || target >= len(m.allItems) {
+		return m, nil
+	}
+	return m, movePlaylistEntry(m.client, m.currentPlaylistID(), entryID, target)
+}
+
+// handlePlaylistEntryMoved applies a move or removal (index -1) to the list, keeping
+// the cursor on the moved entry.
+func (m model) handlePlaylistEntryMoved(msg playlistEntryMovedMsg) (model, tea.Cmd) {
+	if msg.playlistID != m.currentPlaylistID() {
+		return m, nil
+	}
+	index := entryIndex(m.allItems, msg.entryID)
+	if index < 0 {
+		return m, nil
+	}
+
+	entry := m.allItems[index]
+	m.allItems = slices.Delete(slices.Clone(m.allItems), index, index+1)
+	if msg.index >= 0 {
+		m.allItems = slices.Insert(m.allItems, min(msg.index, len(m.allItems)), entry)
+	}
+	m.applyFilter()
+	if msg.index >= 0 {
+		m.cursor = entryIndex(m.items, msg.entryID)
+	}
+	m.clampCursor()
+	m.updateViewport()
+	return m, nil
+}
@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Banh-Canh/jtui/internal/config"
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// profilePickerState holds the state of the server profile picker (ProfileView).
+type profilePickerState struct {
+	names        []string
+	cursor       int
+	previousView ViewType
+	switching    bool
+	err          error
+}
+
+// switchProfile connects to the server of another profile in the background.
+func switchProfile(name string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := jellyfin.PrepareFromConfig(config.ProfileGetter(name))
+		return profileSwitchedMsg{client: client, name: name, err: err}
+	}
+}
+
+func (m model) openProfilePicker() (model, tea.Cmd) {
+	names := config.ProfileNames()
+	m.profiles = profilePickerState{
+		names:        names,
+		cursor:       max(slices.Index(names, config.ActiveProfile()), 0),
+		previousView: m.currentView,
+	}
+	m.currentView = ProfileView
+	return m, nil
+}
+
+func (m model) handleProfileKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	if m.profiles.switching {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "P":
+		m.currentView = m.profiles.previousView
+		m.profiles = profilePickerState{}
+		if m.currentView == LoginView && !m.login.usePassword {
+			// The code was dropped when the picker opened
+			return m.regenerateQuickConnect()
+		}
+	case "up", "k":
+		if m.profiles.cursor > 0 {
+			m.profiles.cursor--
+		}
+	case "down", "j":
+		if m.profiles.cursor < len(m.profiles.names)-1 {
+			m.profiles.cursor++
+		}
+	case "enter":
+		if len(m.profiles.names) == 0 {
+			return m, nil
+		}
+		if m.isVideoPlaying {
+			// The player reports to the server of the current profile
+			m.profiles.err = fmt.Errorf("stop playback before switching profiles")
+			return m, nil
+		}
+		m.profiles.switching = true
+		m.profiles.err = nil
+		return m, switchProfile(m.profiles.names[m.profiles.cursor])
+	}
+	return m, nil
+}
+
+// handleProfileSwitched replaces the client and restarts the TUI state for the new profile.
+// Downloads still queued for the previous profile keep running into its own directory.
+// Nothing is playing (see handleProfileKey), so no progress updates are started.
+func (m model) handleProfileSwitched(msg profileSwitchedMsg) (model, tea.Cmd) {
+	err := msg.err
+	if err == nil {
+		err = config.SetActiveProfile(msg.name)
+	}
+	if err != nil {
+		m.profiles.switching = false
+		m.profiles.err = err
+		return m, nil
+	}
+	m.cancelRequests()
+	if m.client != nil {
+		m.client.Download.Queue.OnUpdate = nil
+	}
+	if globalImageArea != nil {
+		clearImageArea(globalImageArea)
+		globalImageArea = nil
+	}
+
+	width, height := m.width, m.height
+	m = newModel(msg.client, nil)
+	m.width, m.height = width, height
+	m.updateViewport()
+	return m, m.start()
+}
+
+func (m model) renderProfilePicker() string {
+	var b strings.Builder
+
+	b.WriteString(headerTitleStyle.Render("󰚯 JTUI — Server profiles"))
+	b.WriteString("\n\n")
+
+	if len(m.profiles.names) == 0 {
+		b.WriteString(dimStyle.Render("No profiles configured"))
+	}
+	active := config.ActiveProfile()
+	for i, name := range m.profiles.names {
+		marker := "  "
+		if name == active {
+			marker = "● "
+		}
+		url := config.ProfileGetter(name)("jellyfin.server_url")
+		line := fmt.Sprintf("%s%-12s %s", marker, name, url)
+		if i == m.profiles.cursor {
+			b.WriteString(selectedStyle.Render(line))
+		} else {
+			b.WriteString(itemStyle.Render(line))
+		}
+		if i < len(m.profiles.names)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	if m.profiles.switching {
+		b.WriteString("\n\n")
+		b.WriteString(infoStyle.Render("Connecting..."))
+	}
+	if m.profiles.err != nil {
+		b.WriteString("\n\n")
+		b.WriteString(loginErrorStyle.Render(fmt.Sprintf("Error: %v", m.profiles.err)))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(dimStyle.Render("↑↓/jk select • enter switch • esc back"))
+
+	box := loginBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
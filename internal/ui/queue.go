@@ -0,0 +1,412 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// Albums, artists, playlists, seasons, series and collections play as a queue in a
+// single mpv process. mpv starts as soon as the first entry can be played; fillQueue
+// then negotiates the streams of the other entries a batch at a time and adds them to
+// the mpv playlist, leaving out the ones that fail. trackQueue follows the playlist
+// position of mpv to report the playback of each entry to the server.
+
+// queueTrack is an entry of the play queue and where mpv plays it from.
+type queueTrack struct {
+	item   jellyfin.DetailedItem
+	stream *jellyfin.StreamInfo // nil until resolved
+}
+
+// queueState is a play queue, shared by the goroutines filling and tracking it.
+type queueState struct {
+	cmd       *exec.Cmd
+	tracks    []queueTrack
+	mu        sync.Mutex // guards playlist, and keeps it in step with the mpv playlist
+	playlist  []int      // index in tracks of each entry of the mpv playlist
+	playlist0 int        // entry mpv was started with
+}
+
+// queueNegotiations is how many streams of a queue are negotiated at once.
+const queueNegotiations = 4
+
+// playQueue plays items as an mpv playlist, starting with items[start].
+func playQueue(client *jellyfin.Client, items []jellyfin.DetailedItem, start int) tea.Cmd {
+	return func() tea.Msg {
+		return startQueue(client, items, start)
+	}
+}
+
+// startQueue starts mpv on the first entry that can be played from items[start] on.
+// Queues made only of music play without video.
+func startQueue(client *jellyfin.Client, items []jellyfin.DetailedItem, start int) tea.Msg {
+	if len(items) == 0 {
+		return errMsg{fmt.Errorf("nothing to play")}
+	}
+	if start < 0 || start >= len(items) {
+		start = 0
+	}
+	closeRunningMpv()
+
+	q := &queueState{tracks: make([]queueTrack, len(items))}
+	audioOnly := true
+	for i, item := range items {
+		q.tracks[i].item = item
+		audioOnly = audioOnly && item.IsAudio()
+	}
+	first := -1
+	var errs []error
+	for i := start; i < len(items) && first < 0; i++ {
+		if err := resolveTrack(client, &q.tracks[i]); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		first = i
+	}
+	if first < 0 {
+		return errMsg{fmt.Errorf("failed to play the queue: %w", errors.Join(errs...))}
+	}
+
+	args := []string{"--input-ipc-server=" + mpvSocketPath, "--title=jtui-player"}
+	if audioOnly {
+		args = append(args, "--no-video")
+	} else {
+		args = append(args, languageArgs(client.Playback.GetLanguagePreferences())...)
+	}
+	// Options within --{ and --} only apply to the entry
+	args = append(args, "--{")
+	for _, subtitle := range q.tracks[first].stream.SubtitleFiles {
+		args = append(args, "--sub-file="+subtitle)
+	}
+	if ticks := resumeTicks(q.tracks[first].item); ticks > 0 {
+		args = append(args, fmt.Sprintf("--start=%.2f", ticksToSeconds(ticks)))
+	}
+	args = append(args, q.tracks[first].stream.URL, "--}")
+	cmd := exec.Command("mpv", args...)
+	if err := cmd.Start(); err != nil {
+		return errMsg{fmt.Errorf("failed to start mpv: %w", err)}
+	}
+	registerMpvProcess(cmd)
+	q.cmd = cmd
+	q.playlist = []int{first}
+	q.playlist0 = first
+
+	go fillQueue(client, q, start, first, len(errs))
+	go trackQueue(client, q)
+
+	return queueTrackChangedMsg{item: &q.tracks[first].item}
+}
+
+// resolveTrack finds where to play an entry from: its download, or a stream negotiated
+// with the server.
+func resolveTrack(client *jellyfin.Client, t *queueTrack) error {
+	if strings.HasPrefix(t.item.GetID(), "offline-") {
+		_, filePath, err := client.Download.GetOfflineItemByID(t.item.GetID())
+		if err != nil {
+			return fmt.Errorf("failed to get offline content: %w", err)
+		}
+		t.stream = &jellyfin.StreamInfo{
+			ItemID: t.item.GetID(), URL: filePath, IsLocal: true, PlayMethod: jellyfin.PlayMethodDirectPlay,
+			SubtitleFiles: client.Download.LocalSubtitles(filePath),
+		}
+		return nil
+	}
+	stream, err := client.Playback.GetPlaybackStream(t.item.GetID(), &t.item, jellyfin.StreamOptions{})
+	if err != nil {
+		return err
+	}
+	t.stream = stream
+	return nil
+}
+
+// resumeTicks returns where an entry resumes from, 0 to play it from the start. Music
+// always plays from the start.
+func resumeTicks(item jellyfin.DetailedItem) int64 {
+	if item.IsAudio() || !item.HasResumePosition() {
+		return 0
+	}
+	return item.GetPlaybackPositionTicks()
+}
+
+// fillQueue runs in a goroutine to resolve the entries after the first one, then the
+// ones before it, a batch at a time, and add them to the mpv playlist in queue order.
+// Entries from start up to the first one already failed.
+func fillQueue(client *jellyfin.Client, q *queueState, start, first, failed int) {
+	pending := make([]int, 0, len(q.tracks))
+	for i := first + 1; i < len(q.tracks); i++ {
+		pending = append(pending, i)
+	}
+	for i := range start {
+		pending = append(pending, i)
+	}
+
+	report := func() {
+		if globalProgram != nil {
+			q.mu.Lock()
+			ready := len(q.playlist)
+			q.mu.Unlock()
+			globalProgram.Send(queueFilledMsg{queue: q, ready: ready, failed: failed, total: len(q.tracks)})
+		}
+	}
+	report()
+	for batch := range slices.Chunk(pending, queueNegotiations) {
+		if !mpvProcessTracked(q.cmd) {
+			return
+		}
+		errs := make([]error, len(batch))
+		var wg sync.WaitGroup
+		for j, i := range batch {
+			wg.Go(func() {
+				errs[j] = resolveTrack(client, &q.tracks[i])
+			})
+		}
+		wg.Wait()
+		for j, i := range batch {
+			if errs[j] != nil {
+				failed++
+				continue
+			}
+			if err := q.add(i); err != nil {
+				return // mpv is gone
+			}
+		}
+		report()
+	}
+}
+
+// add adds a resolved entry to the mpv playlist, before the entries that follow it in
+// the queue.
+func (q *queueState) add(i int) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !mpvProcessTracked(q.cmd) {
+		return fmt.Errorf("the queue was closed")
+	}
+	if err := runMpvCommand("loadfile", q.tracks[i].stream.URL, "append"); err != nil {
+		return err
+	}
+	at, _ := slices.BinarySearch(q.playlist, i)
+	if at < len(q.playlist) {
+		if err := runMpvCommand("playlist-move", strconv.Itoa(len(q.playlist)), strconv.Itoa(at)); err != nil {
+			return err
+		}
+	}
+	q.playlist = slices.Insert(q.playlist, at, i)
+	return nil
+}
+
+// entryAt returns the queue index of the entry mpv plays, false if it can't be told.
+func (q *queueState) entryAt() (int, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	pos, ok := getMpvIntProperty("playlist-pos")
+	if !ok || pos < 0 || pos >= len(q.playlist) {
+		return 0, false
+	}
+	return q.playlist[pos], true
+}
+
+// prepareTrack loads the external subtitles of an entry added while mpv was running,
+// and resumes it the first time it plays. Both need the file to be loaded.
+func prepareTrack(t queueTrack, resume bool) {
+	for _, subtitle := range t.stream.SubtitleFiles {
+		_ = runMpvCommand("sub-add", subtitle, "auto")
+	}
+	if ticks := resumeTicks(t.item); resume && ticks > 0 {
+		_ = runMpvCommand("seek", fmt.Sprintf("%.2f", ticksToSeconds(ticks)), "absolute")
+	}
+}
+
+// trackQueue runs in a goroutine to follow mpv through the queue and report the
+// progress of each entry.
+func trackQueue(client *jellyfin.Client, q *queueState) {
+	exited := make(chan error, 1)
+	go func() {
+		exited <- q.cmd.Wait()
+	}()
+	defer func() {
+		if globalProgram != nil {
+			globalProgram.Send(queueFilledMsg{queue: q})
+		}
+	}()
+
+	current := q.playlist[0]
+	prepared := true                      // the first entry got its options on the command line
+	played := map[int]bool{current: true} // entries resumed already
+	var position, duration float64
+	var credits int64 // start of the credits of the current track, 0 if unknown
+	track := func() queueTrack { return q.tracks[current] }
+	startTrack := func() {
+		if !track().stream.IsLocal {
+			client.Playback.ReportStart(track().stream)
+		}
+		credits = 0
+		if !track().item.IsAudio() {
+			credits = outroStart(fetchSegments(client, track().item.GetID()))
+		}
+	}
+	finishTrack := func() {
+		itemID := track().item.GetID()
+		if !track().stream.IsLocal {
+			client.Playback.ReportStop(track().stream, int64(position*10000000))
+		}
+		completed := duration > 0 && (position/duration)*100 >= 90.0 || credits > 0 && int64(position*10000000) >= credits
+		if completed && !strings.HasPrefix(itemID, "offline-") && client.IsAuthenticated() {
+			client.Playback.MarkWatched(itemID)
+			if globalProgram != nil {
+				globalProgram.Send(videoCompletedMsg{itemID: itemID, queued: true})
+			}
+		}
+	}
+
+	startTrack()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for polls := 1; ; polls++ {
+		select {
+		case err := <-exited:
+			// A queue closed by jtui, e.g. to play something else, was killed: that is no failure
+			closedByUs := !unregisterMpvProcess(q.cmd)
+			finishTrack()
+			if err != nil && !closedByUs && globalProgram != nil {
+				globalProgram.Send(errMsg{fmt.Errorf("mpv playback failed: %w", err)})
+			}
+			return
+		case <-ticker.C:
+		}
+
+		// Once jtui closed the queue, the IPC socket may already belong to the next player
+		if !mpvProcessTracked(q.cmd) {
+			<-exited
+			finishTrack()
+			return
+		}
+
+		if entry, ok := q.entryAt(); ok && entry != current {
+			finishTrack()
+			current, position, duration, prepared = entry, 0, 0, false
+			if globalProgram != nil {
+				globalProgram.Send(queueTrackChangedMsg{item: &q.tracks[current].item})
+			}
+			startTrack()
+			continue
+		}
+
+		if p := getMpvFloatProperty("time-pos"); p > 0 {
+			position = p
+		}
+		if d := getMpvFloatProperty("duration"); d > 0 {
+			duration = d
+			// The entry mpv started with keeps the options it got on the command line
+			if !prepared && current != q.playlist0 {
+				prepareTrack(track(), !played[current])
+				prepared, played[current] = true, true
+			}
+		}
+		if polls%5 == 0 && position > 0 && !track().stream.IsLocal {
+			client.Playback.ReportProgress(track().stream, int64(position*10000000))
+		}
+	}
+}
+
+// getMpvIntProperty returns an integer property of mpv, and false if it can't be read.
+func getMpvIntProperty(property string) (int, bool) {
+	resp, err := mpvIPCCommand([]string{"get_property", property}, 1024)
+	if err != nil {
+		return 0, false
+	}
+	data, ok := resp["data"].(float64)
+	return int(data), ok
+}
+
+func nextTrack() tea.Cmd {
+	return func() tea.Msg {
+		if err := sendMpvCommand("playlist-next"); err != nil {
+			return errMsg{fmt.Errorf("failed to skip to the next entry: %w", err)}
+		}
+		return nil
+	}
+}
+
+func previousTrack() tea.Cmd {
+	return func() tea.Msg {
+		if err := sendMpvCommand("playlist-prev"); err != nil {
+			return errMsg{fmt.Errorf("failed to go back to the previous entry: %w", err)}
+		}
+		return nil
+	}
+}
+
+// handlePlayQueue plays the album, the artist's tracks shuffled, the playlist, the
+// videos of the season, series or collection, or the track list under the cursor. It
+// returns false for items that do not play as a queue.
+func (m model) handlePlayQueue() (model, tea.Cmd, bool) {
+	if len(m.items) == 0 || m.cursor >= len(m.items) {
+		return m, nil, false
+	}
+	item := m.items[m.cursor]
+	switch itemTypeOf(item) {
+	case jellyfin.ItemTypeMusicAlbum:
+		return m, playAlbum(m.client, item.GetID()), true
+	case jellyfin.ItemTypeMusicArtist:
+		return m, shuffleArtist(m.client, item.GetID()), true
+	case jellyfin.ItemTypePlaylist:
+		return m, playPlaylist(m.client, item.GetID()), true
+	case jellyfin.ItemTypeAudio:
+		m, cmd := m.playTrackList()
+		return m, cmd, true
+	}
+	if playsAll(itemTypeOf(item)) {
+		return m, playAll(m.client, item, m.seriesIDOf(item), false), true
+	}
+	return m, nil, false
+}
+
+// handleQueueFilled keeps how much of the playing queue is ready, for the progress bar.
+func (m model) handleQueueFilled(msg queueFilledMsg) (model, tea.Cmd) {
+	if msg.total == 0 && msg.queue != m.queueFill.queue {
+		return m, nil // End of a queue replaced by another one
+	}
+	m.queueFill = msg
+	return m, nil
+}
+
+// queueFillInfo describes the entries of the play queue still being negotiated or
+// left out, empty once all of them are ready.
+func (m model) queueFillInfo() string {
+	f := m.queueFill
+	var info string
+	if pending := f.total - f.ready - f.failed; pending > 0 {
+		info = fmt.Sprintf("📜 %d/%d entries ready", f.ready, f.total)
+	}
+	if f.failed > 0 {
+		if info != "" {
+			info += ", "
+		} else {
+			info = "📜 "
+		}
+		info += fmt.Sprintf("%d could not be played", f.failed)
+	}
+	return info
+}
+
+// handleQueueTrackChanged shows the entry mpv moved to. Albums, artists and playlists
+// only start playing once their entries are loaded, so their first entry also starts
+// the progress updates. Live streams are reported the same way once opened.
+func (m model) handleQueueTrackChanged(msg queueTrackChangedMsg) (model, tea.Cmd) {
+	updating := m.currentPlayingItem != nil
+	m.currentPlayingItem = msg.item
+	if updating {
+		return m, nil
+	}
+	return m, createDelayedProgressUpdateCmd()
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// segmentState holds the media segments of the playing item and the skip prompt.
+type segmentState struct {
+	itemID   string
+	segments []jellyfin.MediaSegment
+	handled  map[string]bool        // segments skipped or dismissed, by ID
+	prompt   *jellyfin.MediaSegment // segment offered to skip, nil if none
+	next     *jellyfin.DetailedItem // episode after the playing one, offered in its credits
+}
+
+// fetchSegments returns the media segments of an item and hands them to the TUI. It
+// runs in the goroutines tracking playback. Offline items and servers older than
+// Jellyfin 10.10 have no segments.
+func fetchSegments(client *jellyfin.Client, itemID string) []jellyfin.MediaSegment {
+	if client.IsOfflineMode() || strings.HasPrefix(itemID, "offline-") {
+		return nil
+	}
+	segments, err := client.Playback.GetMediaSegments(itemID)
+	if err != nil {
+		return nil
+	}
+	if globalProgram != nil {
+		globalProgram.Send(segmentsLoadedMsg{itemID: itemID, segments: segments})
+	}
+	return segments
+}
+
+// outroStart returns where the credits start, 0 if the segments don't tell.
+func outroStart(segments []jellyfin.MediaSegment) int64 {
+	for _, segment := range segments {
+		if segment.Type == jellyfin.SegmentOutro && segment.StartTicks > 0 {
+			return segment.StartTicks
+		}
+	}
+	return 0
+}
+
+func loadNextEpisode(client *jellyfin.Client, episode *jellyfin.DetailedItem) tea.Cmd {
+	return func() tea.Msg {
+		next, err := client.Items.GetNextEpisode(episode)
+		if err != nil || next == nil {
+			return nil
+		}
+		return nextEpisodeLoadedMsg{itemID: episode.GetID(), next: next}
+	}
+}
+
+// handleSegmentsLoaded keeps the segments of the playing item, and looks for the next
+// episode to offer when the credits start.
+func (m model) handleSegmentsLoaded(msg segmentsLoadedMsg) (model, tea.Cmd) {
+	m.segments = segmentState{
+		itemID:   msg.itemID,
+		segments: msg.segments,
+		handled:  make(map[string]bool),
+	}
+	item := m.currentPlayingItem
+	if item == nil || item.GetID() != msg.itemID || item.Type != "Episode" || outroStart(msg.segments) == 0 {
+		return m, nil
+	}
+	return m, loadNextEpisode(m.client, item)
+}
+
+func (m model) handleNextEpisodeLoaded(msg nextEpisodeLoadedMsg) (model, tea.Cmd) {
+	if m.segments.itemID == msg.itemID {
+		m.segments.next = msg.next
+	}
+	return m, nil
+}
+
+// updateSegments acts on the segment playback is in, on each progress update: it is
+// skipped or offered to skip, as configured for its type.
+func (m model) updateSegments() (model, tea.Cmd) {
+	s := &m.segments
+	s.prompt = nil
+	if !m.isVideoPlaying || m.currentPlayingItem == nil || s.itemID != m.currentPlayingItem.GetID() {
+		return m, nil
+	}
+
+	position := int64(m.currentPlayPosition * 10000000)
+	for i, segment := range s.segments {
+		if !segment.Contains(position) || s.handled[segment.ID] {
+			continue
+		}
+		switch m.client.GetConfig().SegmentAction(segment.Type) {
+		case jellyfin.SegmentActionSkip:
+			s.handled[segment.ID] = true
+			return m, seekTo(ticksToSeconds(segment.EndTicks))
+		case jellyfin.SegmentActionAsk:
+			s.prompt = &s.segments[i]
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// handleSkipSegment accepts the prompt: in the credits, the next episode plays if
+// there is one, otherwise the segment is skipped.
+func (m model) handleSkipSegment() (model, tea.Cmd) {
+	s := &m.segments
+	segment := s.prompt
+	if segment == nil || !m.isVideoPlaying {
+		return m, nil
+	}
+	s.handled[segment.ID] = true
+	s.prompt = nil
+	if segment.Type == jellyfin.SegmentOutro && s.next != nil {
+		next := s.next
+		m.segments = segmentState{}
+		m.currentPlayingItem = next
+		return m, tea.Batch(playItem(m.client, next.GetID(), 0), createDelayedProgressUpdateCmd())
+	}
+	return m, seekTo(ticksToSeconds(segment.EndTicks))
+}
+
+// segmentPrompt returns the text of the skip prompt, empty if there is none.
+func (m model) segmentPrompt() string {
+	s := m.segments
+	if s.prompt == nil {
+		return ""
+	}
+	if s.prompt.Type == jellyfin.SegmentOutro && s.next != nil {
+		name := s.next.GetName()
+		if season, episode := s.next.GetSeasonNumber(), s.next.GetEpisodeNumber(); episode > 0 {
+			name = fmt.Sprintf("S%02dE%02d %s", season, episode, name)
+		}
+		return fmt.Sprintf("⏭ Next episode: %s [i]", name)
+	}
+	names := map[string]string{
+		jellyfin.SegmentIntro:   "intro",
+		jellyfin.SegmentOutro:   "credits",
+		jellyfin.SegmentRecap:   "recap",
+		jellyfin.SegmentPreview: "preview",
+	}
+	return fmt.Sprintf("⏭ Skip %s [i]", names[s.prompt.Type])
+}
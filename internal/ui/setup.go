@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// discoveryTimeout is how long the wizard waits for servers to answer the discovery probe
+const discoveryTimeout = 3 * time.Second
+
+type setupStep int
+
+const (
+	setupDiscovering setupStep = iota
+	setupChoose
+	setupManual
+	setupVerifying
+)
+
+// setupModel is the first-run wizard. It runs as its own program before the
+// main TUI because there is no usable server configuration yet.
+type setupModel struct {
+	step      setupStep
+	servers   []jellyfin.DiscoveredServer
+	cursor    int // len(servers) is the "enter URL manually" entry
+	input     string
+	verifying string
+	previous  setupStep
+	err       error
+	result    string
+	width     int
+	height    int
+}
+
+type serversDiscoveredMsg struct {
+	servers []jellyfin.DiscoveredServer
+	err     error
+}
+
+type serverVerifiedMsg struct {
+	url string
+	err error
+}
+
+// Setup runs the setup wizard and returns the chosen server URL, or an empty
+// string if the user cancelled. The caller is responsible for saving it.
+func Setup(initialURL string) (string, error) {
+	p := tea.NewProgram(setupModel{input: initialURL}, tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		return "", fmt.Errorf("setup wizard failed: %w", err)
+	}
+	return final.(setupModel).result, nil
+}
+
+func discoverServers() tea.Msg {
+	servers, err := jellyfin.DiscoverServers(discoveryTimeout)
+	return serversDiscoveredMsg{servers: servers, err: err}
+}
+
+func verifyServer(url string) tea.Cmd {
+	return func() tea.Msg {
+		client, err := jellyfin.NewClientBuilder().WithServerURL(url).Build()
+		if err == nil {
+			err = client.Auth.TestConnection()
+		}
+		return serverVerifiedMsg{url: url, err: err}
+	}
+}
+
+func (m setupModel) Init() tea.Cmd {
+	return discoverServers
+}
+
+func (m setupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case serversDiscoveredMsg:
+		m.servers = msg.servers
+		m.err = msg.err
+		m.cursor = 0
+		m.step = setupChoose
+		if len(m.servers) == 0 {
+			m.step = setupManual
+		}
+	case serverVerifiedMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("%s is not reachable: %w", msg.url, msg.err)
+			m.step = m.previous
+			return m, nil
+		}
+		m.result = msg.url
+		return m, tea.Quit
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m setupModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	switch m.step {
+	case setupChoose:
+		switch msg.String() {
+		case "q", "esc":
+			return m, tea.Quit
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.servers) {
+				m.cursor++
+			}
+		case "r":
+			m.step = setupDiscovering
+			m.err = nil
+			return m, discoverServers
+		case "enter":
+			if m.cursor == len(m.servers) {
+				m.step = setupManual
+				m.err = nil
+				return m, nil
+			}
+			return m.verify(m.servers[m.cursor].Address)
+		}
+	case setupManual:
+		switch msg.Type {
+		case tea.KeyEsc:
+			if len(m.servers) == 0 {
+				return m, tea.Quit
+			}
+			m.step = setupChoose
+			m.err = nil
+		case tea.KeyEnter:
+			url := jellyfin.NormalizeServerURL(m.input)
+			if url == "" {
+				return m, nil
+			}
+			return m.verify(url)
+		case tea.KeyBackspace:
+			if r := []rune(m.input); len(r) > 0 {
+				m.input = string(r[:len(r)-1])
+			}
+		case tea.KeyCtrlR:
+			m.step = setupDiscovering
+			m.err = nil
+			return m, discoverServers
+		case tea.KeyRunes, tea.KeySpace:
+			m.input += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+func (m setupModel) verify(url string) (tea.Model, tea.Cmd) {
+	m.previous = m.step
+	m.step = setupVerifying
+	m.verifying = url
+	m.err = nil
+	return m, verifyServer(url)
+}
+
+func (m setupModel) View() string {
+	var b strings.Builder
+
+	b.WriteString(headerTitleStyle.Render("󰚯 JTUI — Setup"))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render("Choose the Jellyfin server to connect to"))
+	b.WriteString("\n\n")
+
+	var help string
+	switch m.step {
+	case setupDiscovering:
+		b.WriteString(infoStyle.Render(fmt.Sprintf("Looking for servers on the local network (UDP %d)...", jellyfin.DiscoveryPort)))
+	case setupChoose:
+		for i, server := range m.servers {
+			line := fmt.Sprintf("%-20s %s", server.Name, server.Address)
+			if i == m.cursor {
+				b.WriteString(selectedStyle.Render(line))
+			} else {
+				b.WriteString(itemStyle.Render(line))
+			}
+			b.WriteString("\n")
+		}
+		if m.cursor == len(m.servers) {
+			b.WriteString(selectedStyle.Render("Enter a URL manually..."))
+		} else {
+			b.WriteString(itemStyle.Render("Enter a URL manually..."))
+		}
+		help = "↑↓/jk select • enter connect • r rescan • esc cancel"
+	case setupManual:
+		if len(m.servers) == 0 {
+			b.WriteString(dimStyle.Render("No servers answered on the local network."))
+			b.WriteString("\n\n")
+		}
+		b.WriteString(selectedStyle.Render(fmt.Sprintf("%-10s %s█", "URL:", m.input)))
+		help = "enter connect • ctrl+r rescan • esc back"
+		if len(m.servers) == 0 {
+			help = "enter connect • ctrl+r rescan • esc cancel"
+		}
+	case setupVerifying:
+		b.WriteString(infoStyle.Render(fmt.Sprintf("Connecting to %s...", m.verifying)))
+	}
+
+	if m.err != nil {
+		b.WriteString("\n\n")
+		b.WriteString(loginErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+	}
+	if help != "" {
+		b.WriteString("\n\n")
+		b.WriteString(dimStyle.Render(help))
+	}
+
+	box := loginBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,234 @@
+package ui
+
+import (
+	"cmp"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// SortMode represents an item sort order. Each library remembers its own mode.
+type SortMode int
+
+const (
+	SortDefault SortMode = iota // order returned by the server
+	SortName
+	SortDateAdded
+	SortYear
+	SortRating
+	SortRuntime
+	SortLastPlayed
+	sortModeCount
+)
+
+// sortModeKeys are the names under which sort modes are saved
+var sortModeKeys = [...]string{"", "name", "date_added", "year", "rating", "runtime", "last_played"}
+
+func (s SortMode) String() string {
+	switch s {
+	case SortName:
+		return "Name"
+	case SortDateAdded:
+		return "Date added"
+	case SortYear:
+		return "Year"
+	case SortRating:
+		return "Rating"
+	case SortRuntime:
+		return "Runtime"
+	case SortLastPlayed:
+		return "Last played"
+	default:
+		return ""
+	}
+}
+
+// apply sets the sort of a server query to the mode. Ties are broken by name, like
+// compare does.
+func (s SortMode) apply(q *jellyfin.ItemsQuery) *jellyfin.ItemsQuery {
+	switch s {
+	case SortName:
+		return q.WithSort(jellyfin.SortAscending, jellyfin.SortByName)
+	case SortDateAdded:
+		return q.WithSort(jellyfin.SortDescending, jellyfin.SortByDateCreated, jellyfin.SortByName)
+	case SortYear:
+		return q.WithSort(jellyfin.SortDescending, jellyfin.SortByProductionYear, jellyfin.SortByName)
+	case SortRating:
+		return q.WithSort(jellyfin.SortDescending, jellyfin.SortByCommunityRating, jellyfin.SortByName)
+	case SortRuntime:
+		return q.WithSort(jellyfin.SortDescending, jellyfin.SortByRuntime, jellyfin.SortByName)
+	case SortLastPlayed:
+		return q.WithSort(jellyfin.SortDescending, jellyfin.SortByDatePlayed, jellyfin.SortByName)
+	}
+	return q
+}
+
+// compare orders two items for client-side sorting. Everything but names sorts
+// newest, longest or best first.
+func (s SortMode) compare(a, b jellyfin.Item) int {
+	da, _ := detailedOf(a)
+	db, _ := detailedOf(b)
+	var c int
+	switch s {
+	case SortDateAdded:
+		c = db.DateCreated.Compare(da.DateCreated)
+	case SortYear:
+		c = cmp.Compare(db.ProductionYear, da.ProductionYear)
+	case SortRating:
+		c = cmp.Compare(db.CommunityRating, da.CommunityRating)
+	case SortRuntime:
+		c = cmp.Compare(db.RunTimeTicks, da.RunTimeTicks)
+	case SortLastPlayed:
+		c = db.UserData.LastPlayedDate.Compare(da.UserData.LastPlayedDate)
+	}
+	if c != 0 {
+		return c
+	}
+	return cmp.Compare(sortName(a), sortName(b))
+}
+
+// sortName returns the name an item sorts by: its sort name from the server, like
+// the server does, or its name for items without one.
+func sortName(item jellyfin.Item) string {
+	if d, ok := detailedOf(item); ok && d.SortName != "" {
+		return strings.ToLower(d.SortName)
+	}
+	return strings.ToLower(item.GetName())
+}
+
+// detailedOf returns the metadata of an item, and false with empty metadata for items
+// without any.
+func detailedOf(item jellyfin.Item) (jellyfin.DetailedItem, bool) {
+	switch di := item.(type) {
+	case jellyfin.DetailedItem:
+		return di, true
+	case *jellyfin.DetailedItem:
+		return *di, true
+	}
+	return jellyfin.DetailedItem{}, false
+}
+
+// itemTypeOf returns the Jellyfin type of an item, empty if unknown.
+func itemTypeOf(item jellyfin.Item) string {
+	switch it := item.(type) {
+	case jellyfin.SimpleItem:
+		return it.Type
+	case *jellyfin.SimpleItem:
+		return it.Type
+	}
+	d, _ := detailedOf(item)
+	return d.Type
+}
+
+// sortKey identifies the library whose sort mode applies: the top-level folder of the
+// current path. The list of libraries itself is never sorted.
+func (m model) sortKey() string {
+	if len(m.currentPath) == 0 {
+		return ""
+	}
+	return m.currentPath[0].id
+}
+
+func (m model) currentSort() SortMode {
+	return m.sortModes[m.sortKey()]
+}
+
+// sortItems sorts the loaded items on the client. Folders loaded page by page are left
+// alone since the server already sorts them, even once all of their pages are in.
+func (m *model) sortItems() {
+	mode := m.currentSort()
+	if mode == SortDefault || m.pagedParentID != "" {
+		return
+	}
+	m.allItems = slices.Clone(m.allItems)
+	slices.SortStableFunc(m.allItems, mode.compare)
+}
+
+func (m model) handleSort() (model, tea.Cmd) {
+	key := m.sortKey()
+	if key == "" || m.loading {
+		return m, nil
+	}
+
+	mode := (m.currentSort() + 1) % sortModeCount
+	if m.sortModes == nil {
+		m.sortModes = make(map[string]SortMode)
+	}
+	m.sortModes[key] = mode
+	saveSortModes(m.client, m.sortModes)
+
+	// Paged folders are sorted by the server, and only the server knows the default
+	// order of a list sorted on the client
+	if m.pagedParentID != "" || mode == SortDefault {
+		m.cancelRequests()
+		m.loading = true
+		cmd := m.loadCurrentFolder()
+		return m, cmd
+	}
+
+	var selectedID string
+	if m.cursor < len(m.items) {
+		selectedID = m.items[m.cursor].GetID()
+	}
+	m.sortItems()
+	m.applyFilter()
+	for i, item := range m.items {
+		if item.GetID() == selectedID {
+			m.cursor = i
+			break
+		}
+	}
+	m.updateViewport()
+	return m, nil
+}
+
+// sortModesPath is where the sort mode of each library is saved, next to the session
+// of the client's profile.
+func sortModesPath(client *jellyfin.Client) string {
+	return filepath.Join(client.CacheDir(), "sort.json")
+}
+
+// loadSortModes reads the saved sort modes, returning an empty set if there are none.
+func loadSortModes(client *jellyfin.Client) map[string]SortMode {
+	modes := make(map[string]SortMode)
+	content, err := os.ReadFile(sortModesPath(client))
+	if err != nil {
+		return modes
+	}
+	var saved map[string]string
+	if err := json.Unmarshal(content, &saved); err != nil {
+		return modes
+	}
+	for libraryID, key := range saved {
+		if i := slices.Index(sortModeKeys[:], key); i > 0 {
+			modes[libraryID] = SortMode(i)
+		}
+	}
+	return modes
+}
+
+// saveSortModes writes the sort modes. Failures are ignored, the modes are only
+// forgotten on the next start.
+func saveSortModes(client *jellyfin.Client, modes map[string]SortMode) {
+	saved := make(map[string]string, len(modes))
+	for libraryID, mode := range modes {
+		if mode != SortDefault {
+			saved[libraryID] = sortModeKeys[mode]
+		}
+	}
+	content, err := json.Marshal(saved)
+	if err != nil {
+		return
+	}
+	path := sortModesPath(client)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	os.WriteFile(path, content, 0o600) // Ignore error - not critical
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// streamLabel describes an audio or subtitle stream, e.g. "jpn AAC stereo (default)".
+func streamLabel(stream jellyfin.MediaStream) string {
+	var parts []string
+	switch {
+	case stream.Language != "":
+		parts = append(parts, stream.Language)
+	case stream.Title != "":
+		parts = append(parts, stream.Title)
+	default:
+		parts = append(parts, "und")
+	}
+	if stream.Codec != "" {
+		parts = append(parts, strings.ToUpper(stream.Codec))
+	}
+	if stream.ChannelLayout != "" {
+		parts = append(parts, stream.ChannelLayout)
+	}
+	var flags []string
+	if stream.IsDefault {
+		flags = append(flags, "default")
+	}
+	if stream.IsForced {
+		flags = append(flags, "forced")
+	}
+	if stream.IsExternal {
+		flags = append(flags, "external")
+	}
+	if len(flags) > 0 {
+		parts = append(parts, "("+strings.Join(flags, ", ")+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+// streamLines describes the video, audio and subtitle streams of an item, one line per
+// type.
+func streamLines(item *jellyfin.DetailedItem) []string {
+	var lines []string
+	if videos := item.StreamsOfType(jellyfin.StreamTypeVideo); len(videos) > 0 {
+		video := videos[0].DisplayTitle
+		if video == "" {
+			video = strings.ToUpper(videos[0].Codec)
+		}
+		lines = append(lines, "Video: "+video)
+	}
+	for _, kind := range []struct{ streamType, name string }{
+		{jellyfin.StreamTypeAudio, "Audio"},
+		{jellyfin.StreamTypeSubtitle, "Subtitles"},
+	} {
+		streams := item.StreamsOfType(kind.streamType)
+		if len(streams) == 0 {
+			continue
+		}
+		labels := make([]string, len(streams))
+		for i, stream := range streams {
+			labels[i] = streamLabel(stream)
+		}
+		lines = append(lines, kind.name+": "+strings.Join(labels, ", "))
+	}
+	return lines
+}
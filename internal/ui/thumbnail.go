@@ -1,9 +1,11 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"image"
 	"image/jpeg"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -11,6 +13,7 @@ import (
 	"time"
 
 	"github.com/blacktop/go-termimg"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/nfnt/resize"
 	"github.com/spf13/viper"
 )
@@ -81,10 +84,7 @@ func getYaziConfig() yaziThumbnailConfig {
 // Halfblock thumbnail (renderYaziStyleThumbnail)
 // ---------------------------------------------------------------------------
 
-func renderYaziStyleThumbnail(imageURL string, width, height int, itemID string) (string, error) {
-	if imageURL == "" {
-		return "", fmt.Errorf("no image URL provided")
-	}
+func renderYaziStyleThumbnail(width, height int, itemID string) (string, error) {
 	config := getYaziConfig()
 
 	if width > config.maxWidth {
@@ -110,7 +110,7 @@ func renderYaziStyleThumbnail(imageURL string, width, height int, itemID string)
 
 	processedFile := fmt.Sprintf("/tmp/jtui_yazi_%s_%dx%d.jpg", itemID, width, height)
 	if _, err := os.Stat(processedFile); os.IsNotExist(err) {
-		if err := downloadAndProcessImageForTerminal(imageURL, processedFile, width, height, config); err != nil {
+		if err := processImageForTerminal(thumbnailSourcePath(itemID), processedFile, width, height, config); err != nil {
 			return "", fmt.Errorf("failed to process image: %w", err)
 		}
 	}
@@ -139,15 +139,12 @@ func renderYaziStyleThumbnail(imageURL string, width, height int, itemID string)
 // Kitty protocol rendering
 // ---------------------------------------------------------------------------
 
-func renderKittyImageAt(imageURL string, x, y, width, height int, itemID string) error {
-	if imageURL == "" {
-		return fmt.Errorf("no image URL provided")
-	}
+func renderKittyImageAt(x, y, width, height int, itemID string) error {
 	config := getYaziConfig()
 
 	processedFile := fmt.Sprintf("/tmp/jtui_kitty_%s_%dx%d.jpg", itemID, width, height)
 	if _, err := os.Stat(processedFile); os.IsNotExist(err) {
-		if err := downloadAndProcessImageForTerminal(imageURL, processedFile, width, height, config); err != nil {
+		if err := processImageForTerminal(thumbnailSourcePath(itemID), processedFile, width, height, config); err != nil {
 			return fmt.Errorf("failed to process image: %w", err)
 		}
 	}
@@ -195,11 +192,6 @@ func (m model) renderKittyImage(leftWidth, rightWidth, contentHeight int) {
 		return
 	}
 
-	imageURL := m.client.Items.GetImageURL(m.currentDetails.GetID(), "Primary", m.currentDetails.ImageTags.Primary)
-	if imageURL == "" {
-		return
-	}
-
 	rightPanelX := leftWidth + 2
 	rightPanelY := 4
 
@@ -225,7 +217,7 @@ func (m model) renderKittyImage(leftWidth, rightWidth, contentHeight int) {
 	}
 
 	currentItemID := m.currentDetails.GetID()
-	if err := renderKittyImageAt(imageURL, rightPanelX, rightPanelY, thumbWidth, thumbHeight, currentItemID); err == nil {
+	if err := renderKittyImageAt(rightPanelX, rightPanelY, thumbWidth, thumbHeight, currentItemID); err == nil {
 		globalImageArea = &imageArea{
 			x:      rightPanelX,
 			y:      rightPanelY,
@@ -240,12 +232,36 @@ func (m model) renderKittyImage(leftWidth, rightWidth, contentHeight int) {
 // Image processing
 // ---------------------------------------------------------------------------
 
-func downloadAndProcessImageForTerminal(
-	imageURL, outputPath string,
-	termWidth, termHeight int,
-	config yaziThumbnailConfig,
-) error {
-	resp, err := imageDownloadClient.Get(imageURL)
+// thumbnailSourcePath is where the original image of an item is cached once fetched.
+func thumbnailSourcePath(itemID string) string {
+	return fmt.Sprintf("/tmp/jtui_img_%s.jpg", itemID)
+}
+
+// fetchThumbnail downloads the image of an item into the local cache in the
+// background, so rendering never waits on the network. Thumbnails are best
+// effort: failures and cancellations produce no message.
+func fetchThumbnail(ctx context.Context, imageURL, itemID string) tea.Cmd {
+	return func() tea.Msg {
+		if imageURL == "" {
+			return nil
+		}
+		sourcePath := thumbnailSourcePath(itemID)
+		if _, err := os.Stat(sourcePath); err == nil {
+			return thumbnailFetchedMsg{itemID: itemID}
+		}
+		if err := downloadImage(ctx, imageURL, sourcePath); err != nil {
+			return nil
+		}
+		return thumbnailFetchedMsg{itemID: itemID}
+	}
+}
+
+func downloadImage(ctx context.Context, imageURL, outputPath string) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", imageURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create image request: %w", err)
+	}
+	resp, err := imageDownloadClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("failed to download image: %w", err)
 	}
@@ -255,7 +271,33 @@ func downloadAndProcessImageForTerminal(
 		return fmt.Errorf("server returned HTTP %d for image", resp.StatusCode)
 	}
 
-	img, _, err := image.Decode(resp.Body)
+	// Write to a temporary file so a cancelled download never leaves a truncated image behind
+	tmpPath := outputPath + ".part"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create image file: %w", err)
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to download image: %w", err)
+	}
+	file.Close()
+	return os.Rename(tmpPath, outputPath)
+}
+
+func processImageForTerminal(
+	sourcePath, outputPath string,
+	termWidth, termHeight int,
+	config yaziThumbnailConfig,
+) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return fmt.Errorf("image not fetched yet: %w", err)
+	}
+	defer source.Close()
+
+	img, _, err := image.Decode(source)
 	if err != nil {
 		return fmt.Errorf("failed to decode image: %w", err)
 	}
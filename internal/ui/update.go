@@ -1,7 +1,9 @@
 package ui
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,8 +11,8 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/spf13/viper"
 
+	"github.com/Banh-Canh/jtui/internal/config"
 	"github.com/Banh-Canh/jtui/pkg/jellyfin"
 )
 
@@ -19,7 +21,7 @@ func newModel(client *jellyfin.Client, err error) model {
 		return model{err: err, thumbnailCache: make(map[string]string)}
 	}
 
-	return model{
+	m := model{
 		client:              client,
 		currentView:         LibraryView,
 		items:               []jellyfin.Item{},
@@ -30,13 +32,18 @@ func newModel(client *jellyfin.Client, err error) model {
 		viewport:            15,
 		thumbnailCache:      make(map[string]string),
 		cachedDownloadDirty: true,
+		sortModes:           loadSortModes(client),
 	}
+	if client.NeedsLogin() {
+		m.currentView = LoginView
+		m.loading = false
+		m.login = newLoginState(client)
+	}
+	return m
 }
 
 func initialModel() model {
-	client, err := jellyfin.ConnectFromConfig(func(key string) string {
-		return viper.GetString(key)
-	})
+	client, err := jellyfin.PrepareFromConfig(config.ProfileGetter(config.ActiveProfile()))
 	return newModel(client, err)
 }
 
@@ -48,7 +55,15 @@ func (m model) Init() tea.Cmd {
 	if m.err != nil {
 		return nil
 	}
+	if m.currentView == LoginView {
+		return m.start()
+	}
+	return tea.Batch(m.start(), createProgressUpdateCmd())
+}
 
+// start hooks the download queue of the client up to the program and returns the
+// commands loading the first screen and starting the connectivity monitor.
+func (m model) start() tea.Cmd {
 	// Set up download queue notification callback
 	m.client.Download.Queue.OnUpdate = func(status jellyfin.QueueStatus) {
 		if globalProgram != nil {
@@ -56,9 +71,19 @@ func (m model) Init() tea.Cmd {
 		}
 	}
 
+	if m.currentView == LoginView {
+		if m.login.usePassword {
+			return connectivityTick(m.client)
+		}
+		return tea.Batch(
+			startQuickConnect(m.client, m.login.seq),
+			connectivityTick(m.client),
+		)
+	}
+
 	return tea.Batch(
 		loadLibraries(m.client),
-		createProgressUpdateCmd(),
+		connectivityTick(m.client),
 	)
 }
 
@@ -66,6 +91,7 @@ func (m model) Init() tea.Cmd {
 // The actual API call only fires when the debounce timer elapses and the
 // sequence number still matches (i.e. the user stopped moving).
 func (m *model) scheduleDetailLoad(itemID string) tea.Cmd {
+	m.cancelDetailRequests()
 	m.detailSeq++
 	seq := m.detailSeq
 	m.pendingDetailID = itemID
@@ -74,13 +100,54 @@ func (m *model) scheduleDetailLoad(itemID string) tea.Cmd {
 	})
 }
 
+// detailContext cancels the in-flight detail and thumbnail requests, which are
+// stale once the cursor moves, and returns the context for the next ones.
+func (m *model) detailContext() context.Context {
+	m.cancelDetailRequests()
+	m.detailCtx, m.cancelDetail = context.WithCancel(context.Background())
+	return m.detailCtx
+}
+
+func (m *model) cancelDetailRequests() {
+	if m.cancelDetail != nil {
+		m.cancelDetail()
+		m.cancelDetail = nil
+	}
+}
+
+// searchContext cancels the in-flight search and returns the context for the next one.
+func (m *model) searchContext() context.Context {
+	m.cancelSearchRequest()
+	var ctx context.Context
+	ctx, m.cancelSearch = context.WithCancel(context.Background())
+	return ctx
+}
+
+func (m *model) cancelSearchRequest() {
+	if m.cancelSearch != nil {
+		m.cancelSearch()
+		m.cancelSearch = nil
+	}
+}
+
+// cancelRequests cancels every request tied to the current view.
+func (m *model) cancelRequests() {
+	m.cancelDetailRequests()
+	m.cancelSearchRequest()
+	m.cancelListRequests()
+}
+
 // isVirtualFolder checks if an item ID is a virtual folder.
 func isVirtualFolder(itemID string) bool {
 	return itemID == "virtual-continue-watching" ||
 		itemID == "virtual-next-up" ||
 		itemID == "virtual-recently-added-movies" ||
 		itemID == "virtual-recently-added-shows" ||
-		itemID == "virtual-recently-added-episodes"
+		itemID == "virtual-recently-added-episodes" ||
+		itemID == favoritesFolderID ||
+		itemID == playlistsFolderID ||
+		itemID == liveTvFolderID ||
+		strings.HasPrefix(itemID, favoritesGroupPrefix)
 }
 
 // ---------------------------------------------------------------------------
@@ -125,14 +192,38 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleFoldersLoaded(msg)
 	case itemsLoadedMsg:
 		return m.handleItemsLoaded(msg)
+	case itemsPageLoadedMsg:
+		return m.handleItemsPageLoaded(msg)
+	case itemsPageFailedMsg:
+		return m.handleItemsPageFailed(msg)
 	case detailDebounceMsg:
 		return m.handleDetailDebounce(msg)
 	case itemDetailsLoadedMsg:
 		return m.handleItemDetailsLoaded(msg)
 	case searchResultsMsg:
 		return m.handleSearchResults(msg)
+	case filterOptionsLoadedMsg:
+		return m.handleFilterOptionsLoaded(msg)
+	case playlistsLoadedMsg:
+		return m.handlePlaylistsLoaded(msg)
+	case playlistEditedMsg:
+		return m.handlePlaylistEdited(msg)
+	case playlistEntryMovedMsg:
+		return m.handlePlaylistEntryMoved(msg)
+	case guideLoadedMsg:
+		return m.handleGuideLoaded(msg)
+	case timerUpdatedMsg:
+		return m.handleTimerUpdated(msg)
 
 	case errMsg:
+		if errors.Is(msg.err, jellyfin.ErrUnauthorized) && !m.client.IsOfflineMode() {
+			return m.handleSessionExpired()
+		}
+		if !m.client.IsOfflineMode() && jellyfin.IsConnectionError(msg.err) {
+			// Check whether the server went away before reporting the error
+			m.loading = false
+			return m, checkConnectivity(m.client, msg.err)
+		}
 		m.err = msg.err
 		m.successMsg = ""
 		m.loading = false
@@ -144,6 +235,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case thumbnailLoadedMsg:
 		m.thumbnailCache[msg.cacheKey] = msg.thumbnail
 		return m, nil
+	case thumbnailFetchedMsg:
+		// Nothing to store, the next render picks the image up from the cache file
+		return m, nil
 	case downloadQueueUpdateMsg:
 		m.dlQueueStatus = msg.status
 		if msg.status.Failed > 0 && msg.status.LastError != "" {
@@ -153,12 +247,28 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case watchStatusUpdatedMsg:
 		return m.handleWatchStatusUpdated(msg)
+	case favoriteUpdatedMsg:
+		return m.handleFavoriteUpdated(msg)
 	case playbackProgressMsg:
 		return m.handlePlaybackProgress(msg)
 	case playbackStoppedMsg:
 		return m.handlePlaybackStopped()
+	case segmentsLoadedMsg:
+		return m.handleSegmentsLoaded(msg)
+	case nextEpisodeLoadedMsg:
+		return m.handleNextEpisodeLoaded(msg)
+	case bingeNextMsg:
+		return m.handleBingeNext(msg)
+	case bingeTickMsg:
+		return m.handleBingeTick(msg)
 	case videoCompletedMsg:
 		return m.handleVideoCompleted(msg)
+	case queueTrackChangedMsg:
+		return m.handleQueueTrackChanged(msg)
+	case queueFilledMsg:
+		return m.handleQueueFilled(msg)
+	case liveStreamStartedMsg:
+		return m.handleQueueTrackChanged(queueTrackChangedMsg(msg))
 	case stopPlaybackMsg:
 		return m.handleStopPlayback()
 	case togglePauseMsg:
@@ -167,8 +277,26 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case cycleAudioMsg:
 		return m, nil
+	case quickConnectStartedMsg:
+		return m.handleQuickConnectStarted(msg)
+	case quickConnectPolledMsg:
+		return m.handleQuickConnectPolled(msg)
+	case loginTickMsg:
+		return m.handleLoginTick(msg)
+	case passwordLoginFailedMsg:
+		return m.handlePasswordLoginFailed(msg)
+	case loginSucceededMsg:
+		return m.handleLoginSucceeded(msg)
+	case profileSwitchedMsg:
+		return m.handleProfileSwitched(msg)
+	case connectivityTickMsg:
+		return m.handleConnectivityTick(msg)
+	case connectivityCheckedMsg:
+		return m.handleConnectivityChecked(msg)
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	}
 
 	return m, nil
@@ -180,10 +308,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 func (m model) handleLibrariesLoaded(msg librariesLoadedMsg) (model, tea.Cmd) {
 	m.loading = false
+	m.resetPaging()
 	m.clampCursor()
 
 	virtualItems := []jellyfin.Item{
 		&jellyfin.SimpleItem{Name: "Continue Watching", ID: "virtual-continue-watching", IsFolder: true, Type: "VirtualFolder"},
+		&jellyfin.SimpleItem{Name: "Favorites", ID: favoritesFolderID, IsFolder: true, Type: "VirtualFolder"},
+		&jellyfin.SimpleItem{Name: "Playlists", ID: playlistsFolderID, IsFolder: true, Type: "VirtualFolder"},
 		&jellyfin.SimpleItem{Name: "Next Up", ID: "virtual-next-up", IsFolder: true, Type: "VirtualFolder"},
 		&jellyfin.SimpleItem{
 			Name: "Recently Added Movies", ID: "virtual-recently-added-movies", IsFolder: true, Type: "VirtualFolder",
@@ -196,6 +327,11 @@ func (m model) handleLibrariesLoaded(msg librariesLoadedMsg) (model, tea.Cmd) {
 		},
 	}
 
+	if !m.client.IsOfflineMode() {
+		virtualItems = append(virtualItems,
+			&jellyfin.SimpleItem{Name: "Live TV", ID: liveTvFolderID, IsFolder: true, Type: "VirtualFolder"})
+	}
+
 	m.allItems = append(virtualItems, msg.items...)
 	m.items = m.allItems
 	m.cursor = 0
@@ -208,7 +344,7 @@ func (m model) handleLibrariesLoaded(msg librariesLoadedMsg) (model, tea.Cmd) {
 			m.currentDetails = nil
 		} else {
 			m.detailSeq++
-			return m, loadItemDetails(m.client, itemID, m.detailSeq)
+			return m, loadItemDetails(m.detailContext(), m.client, itemID, m.detailSeq)
 		}
 	}
 	return m, nil
@@ -216,7 +352,9 @@ func (m model) handleLibrariesLoaded(msg librariesLoadedMsg) (model, tea.Cmd) {
 
 func (m model) handleFoldersLoaded(msg foldersLoadedMsg) (model, tea.Cmd) {
 	m.loading = false
+	m.resetPaging()
 	m.allItems = msg.items
+	m.sortItems()
 	m.applyFilter()
 	m.cursor = 0
 	m.viewportOffset = 0
@@ -224,14 +362,25 @@ func (m model) handleFoldersLoaded(msg foldersLoadedMsg) (model, tea.Cmd) {
 	m.refreshItemDownloadCache()
 	if len(m.items) > 0 {
 		m.detailSeq++
-		return m, loadItemDetails(m.client, m.items[0].GetID(), m.detailSeq)
+		return m, loadItemDetails(m.detailContext(), m.client, m.items[0].GetID(), m.detailSeq)
 	}
 	return m, nil
 }
 
 func (m model) handleItemsLoaded(msg itemsLoadedMsg) (model, tea.Cmd) {
+	return m.replaceItems(msg.items, "", 0)
+}
+
+// replaceItems shows a new list. pagedParentID and totalItems describe the folder when
+// items is the first page of a paged listing, and are empty otherwise. They are set
+// before sorting, since the server already sorted such a folder.
+func (m model) replaceItems(items []jellyfin.Item, pagedParentID string, totalItems int) (model, tea.Cmd) {
 	m.loading = false
-	m.allItems = msg.items
+	m.resetPaging()
+	m.pagedParentID = pagedParentID
+	m.totalItems = totalItems
+	m.allItems = items
+	m.sortItems()
 	m.applyFilter()
 	m.cursor = 0
 	m.viewportOffset = 0
@@ -239,7 +388,7 @@ func (m model) handleItemsLoaded(msg itemsLoadedMsg) (model, tea.Cmd) {
 	m.refreshItemDownloadCache()
 	if len(m.items) > 0 {
 		m.detailSeq++
-		return m, loadItemDetails(m.client, m.items[0].GetID(), m.detailSeq)
+		return m, loadItemDetails(m.detailContext(), m.client, m.items[0].GetID(), m.detailSeq)
 	}
 	return m, nil
 }
@@ -247,7 +396,7 @@ func (m model) handleItemsLoaded(msg itemsLoadedMsg) (model, tea.Cmd) {
 func (m model) handleDetailDebounce(msg detailDebounceMsg) (model, tea.Cmd) {
 	if msg.seq == m.detailSeq {
 		m.detailSeq++
-		return m, loadItemDetails(m.client, msg.itemID, m.detailSeq)
+		return m, loadItemDetails(m.detailContext(), m.client, msg.itemID, m.detailSeq)
 	}
 	return m, nil
 }
@@ -296,11 +445,16 @@ func (m model) handleItemDetailsLoaded(msg itemDetailsLoadedMsg) (model, tea.Cmd
 		m.thumbnailCache = newCache
 	}
 
+	if m.currentDetails != nil && m.currentDetails.HasPrimaryImage() && !m.client.IsOfflineMode() && m.detailCtx != nil {
+		imageURL := m.client.Items.GetImageURL(m.currentDetails.GetID(), "Primary", m.currentDetails.ImageTags.Primary)
+		return m, fetchThumbnail(m.detailCtx, imageURL, m.currentDetails.GetID())
+	}
 	return m, nil
 }
 
 func (m model) handleSearchResults(msg searchResultsMsg) (model, tea.Cmd) {
 	m.loading = false
+	m.resetPaging()
 	m.allItems = msg.items
 	m.items = m.allItems
 	m.cursor = 0
@@ -311,7 +465,7 @@ func (m model) handleSearchResults(msg searchResultsMsg) (model, tea.Cmd) {
 	m.refreshItemDownloadCache()
 	if len(m.items) > 0 {
 		m.detailSeq++
-		return m, loadItemDetails(m.client, m.items[0].GetID(), m.detailSeq)
+		return m, loadItemDetails(m.detailContext(), m.client, m.items[0].GetID(), m.detailSeq)
 	}
 	return m, nil
 }
@@ -352,10 +506,14 @@ func (m model) handlePlaybackProgress(msg playbackProgressMsg) (model, tea.Cmd)
 	m.isVideoPlaying = msg.isPlaying
 	m.cachedSubtitleTrack = msg.subtitleTrack
 	m.cachedAudioTrack = msg.audioTrack
+	m.cachedVolume, m.cachedSpeed, m.cachedMuted = msg.volume, msg.speed, msg.muted
 
 	if msg.isPlaying && m.currentPlayingItem == nil && m.currentDetails != nil {
 		m.currentPlayingItem = m.currentDetails
 	}
+	if msg.isPlaying && !wasPlaying {
+		m.binge.stoppedID = ""
+	}
 	if !msg.isPlaying && wasPlaying {
 		m.currentPlayingItem = nil
 		m.currentPlayPosition = 0
@@ -368,7 +526,8 @@ func (m model) handlePlaybackProgress(msg playbackProgressMsg) (model, tea.Cmd)
 		m.currentPlayDuration = 0
 	}
 	if msg.isPlaying || wasPlaying || m.currentPlayingItem != nil {
-		return m, createProgressUpdateCmd()
+		m, segmentCmd := m.updateSegments()
+		return m, tea.Batch(createProgressUpdateCmd(), segmentCmd)
 	}
 	return m, nil
 }
@@ -398,10 +557,18 @@ func (m model) handleVideoCompleted(msg videoCompletedMsg) (model, tea.Cmd) {
 			}
 		}
 	}
-	return m, nil
+	// The player of a single item has exited: don't wait for the next progress update
+	// to notice, binge mode starts right away
+	if !msg.queued && (m.currentPlayingItem == nil || m.currentPlayingItem.GetID() == msg.itemID) {
+		m, _ = m.handlePlaybackStopped()
+	}
+	return m.startBinge(msg)
 }
 
 func (m model) handleStopPlayback() (model, tea.Cmd) {
+	if m.currentPlayingItem != nil {
+		m.binge.stoppedID = m.currentPlayingItem.GetID()
+	}
 	m.isVideoPlaying = false
 	m.currentPlayingItem = nil
 	m.currentPlayPosition = 0
@@ -414,6 +581,33 @@ func (m model) handleStopPlayback() (model, tea.Cmd) {
 // ---------------------------------------------------------------------------
 
 func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd) {
+	if m.currentView == LoginView {
+		return m.handleLoginKey(msg)
+	}
+	if m.currentView == ProfileView {
+		return m.handleProfileKey(msg)
+	}
+	if m.currentView == FilterView {
+		return m.handleFilterKey(msg)
+	}
+	if m.currentView == PlaylistView {
+		return m.handlePlaylistKey(msg)
+	}
+	if m.currentView == GuideView {
+		return m.handleGuideKey(msg)
+	}
+	if m.currentView == VersionView {
+		return m.handleVersionKey(msg)
+	}
+	if m.currentView == ChapterView {
+		return m.handleChapterKey(msg)
+	}
+	if m.currentView == SeekView {
+		return m.handleSeekPromptKey(msg)
+	}
+	if m.currentView == KeysView {
+		return m.handleKeysPageKey(msg)
+	}
 	if m.currentView == SearchView {
 		return m.handleSearchInput(msg)
 	}
@@ -424,15 +618,15 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd) {
 	case "up", "k":
 		return m.handleCursorUp()
 	case "down", "j":
-		return m.handleCursorDown()
+		return loadMoreIfNeeded(m.handleCursorDown())
 	case "g":
 		return m.handleJumpTop()
 	case "G":
-		return m.handleJumpBottom()
+		return loadMoreIfNeeded(m.handleJumpBottom())
 	case "pageup":
 		return m.handlePageUp()
 	case "pagedown":
-		return m.handlePageDown()
+		return loadMoreIfNeeded(m.handlePageDown())
 	case "enter":
 		if len(m.items) > 0 {
 			return m.selectItem()
@@ -447,6 +641,16 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd) {
 		if len(m.items) > 0 && !m.items[m.cursor].GetIsFolder() && m.currentDetails != nil {
 			return m, toggleWatchedStatus(m.client, m.items[m.cursor].GetID(), m.currentDetails)
 		}
+	case "*":
+		return m.handleToggleFavorite()
+	case "+":
+		return m.openPlaylistPicker()
+	case "-":
+		return m.handleRemovePlaylistEntry()
+	case "[":
+		return m.handleMovePlaylistEntry(-1)
+	case "]":
+		return m.handleMovePlaylistEntry(1)
 	case "/":
 		m.currentView = SearchView
 		m.searchQuery = ""
@@ -454,7 +658,15 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd) {
 	case "d":
 		return m.handleDownload()
 	case "f":
-		return m.handleFilter()
+		return loadMoreIfNeeded(m.handleFilter())
+	case "F":
+		return m.openFilterPopup()
+	case "o":
+		return m.handleSort()
+	case "P":
+		return m.openProfilePicker()
+	case "S":
+		return m.handleShuffle()
 	case "s":
 		if m.isVideoPlaying {
 			return m, stopPlayback()
@@ -467,6 +679,49 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (model, tea.Cmd) {
 		if m.isVideoPlaying {
 			return m, cycleAudio()
 		}
+	case "c":
+		return m.openChapterPicker()
+	case "i":
+		if m.binge.next != nil {
+			return m.playBingeNext()
+		}
+		return m.handleSkipSegment()
+	case "esc":
+		if m.binge.next != nil {
+			return m.cancelBinge(), nil
+		}
+	case "left":
+		return m.handleSeek(-shortSeek)
+	case "right":
+		return m.handleSeek(shortSeek)
+	case "shift+left":
+		return m.handleSeek(-longSeek)
+	case "shift+right":
+		return m.handleSeek(longSeek)
+	case "T":
+		return m.openSeekPrompt()
+	case "?":
+		return m.openKeysPage()
+	case "9":
+		return m.handleVolume(-volumeStep)
+	case "0":
+		return m.handleVolume(volumeStep)
+	case "m":
+		return m.handleMute()
+	case "{":
+		return m.handleSpeed(-speedStep)
+	case "}":
+		return m.handleSpeed(speedStep)
+	case "=":
+		return m.handleSpeed(0)
+	case ">":
+		if m.isVideoPlaying {
+			return m, nextTrack()
+		}
+	case "<":
+		if m.isVideoPlaying {
+			return m, previousTrack()
+		}
 	}
 
 	return m, nil
@@ -477,15 +732,17 @@ func (m model) handleSearchInput(msg tea.KeyMsg) (model, tea.Cmd) {
 	case "enter":
 		if m.searchQuery != "" {
 			m.loading = true
-			return m, searchItems(m.client, m.searchQuery)
+			return m, searchItems(m.searchContext(), m.client, m.searchQuery)
 		}
 	case "backspace":
 		if len(m.searchQuery) > 0 {
 			m.searchQuery = m.searchQuery[:len(m.searchQuery)-1]
 		} else {
+			m.cancelSearchRequest()
 			m.currentView = LibraryView
 		}
 	case "escape":
+		m.cancelSearchRequest()
 		m.currentView = LibraryView
 		m.searchQuery = ""
 	case "ctrl+c":
@@ -630,12 +887,11 @@ func (m model) handleSpace() (model, tea.Cmd) {
 	if m.isVideoPlaying {
 		return m, togglePause()
 	}
+	if m, cmd, ok := m.handlePlayQueue(); ok {
+		return m, cmd
+	}
 	if len(m.items) > 0 && !m.items[m.cursor].GetIsFolder() && m.currentDetails != nil {
-		m.currentPlayingItem = m.currentDetails
-		return m, tea.Batch(
-			playItem(m.client, m.items[m.cursor].GetID(), 0),
-			createDelayedProgressUpdateCmd(),
-		)
+		return m.playCurrent(m.items[m.cursor].GetID(), 0)
 	}
 	return m, nil
 }
@@ -646,12 +902,7 @@ func (m model) handleResume() (model, tea.Cmd) {
 	}
 	if len(m.items) > 0 && !m.items[m.cursor].GetIsFolder() &&
 		m.currentDetails != nil && m.currentDetails.HasResumePosition() {
-		resumePosition := m.currentDetails.GetPlaybackPositionTicks()
-		m.currentPlayingItem = m.currentDetails
-		return m, tea.Batch(
-			playItem(m.client, m.items[m.cursor].GetID(), resumePosition),
-			createDelayedProgressUpdateCmd(),
-		)
+		return m.playCurrent(m.items[m.cursor].GetID(), m.currentDetails.GetPlaybackPositionTicks())
 	}
 	return m, nil
 }
@@ -668,12 +919,17 @@ func (m model) handleDownload() (model, tea.Cmd) {
 			return m, downloadSeason(m.client, m.parentSeriesID(), item.GetID(), details.GetName())
 		case "Series":
 			return m, downloadShow(m.client, item.GetID(), details.GetName())
+		case jellyfin.ItemTypeMusicAlbum:
+			return m, downloadAlbum(m.client, item.GetID(), details.GetName())
 		}
 	} else {
 		if downloaded, _, err := m.client.Download.IsDownloaded(m.currentDetails); err == nil && downloaded {
 			return m, removeDownload(m.client, m.currentDetails)
 		}
-		return m, downloadVideo(m.client, m.currentDetails)
+		if m.hasVersions(versionDownload) {
+			return m.openVersionPicker(versionDownload, 0), nil
+		}
+		return m, downloadVideo(m.client, m.currentDetails, "")
 	}
 	return m, nil
 }
@@ -693,7 +949,7 @@ func (m model) handleFilter() (model, tea.Cmd) {
 		itemID := m.items[m.cursor].GetID()
 		if !isVirtualFolder(itemID) {
 			m.detailSeq++
-			return m, loadItemDetails(m.client, itemID, m.detailSeq)
+			return m, loadItemDetails(m.detailContext(), m.client, itemID, m.detailSeq)
 		}
 	}
 	return m, nil
@@ -709,39 +965,25 @@ func (m model) selectItem() (model, tea.Cmd) {
 	}
 	item := m.items[m.cursor]
 
+	if item.GetID() == liveTvFolderID {
+		return m.openGuide()
+	}
 	if item.GetIsFolder() {
-		m.currentPath = append(m.currentPath, pathItem{name: item.GetName(), id: item.GetID()})
+		m.cancelRequests()
+		m.currentPath = append(m.currentPath, pathItem{name: item.GetName(), id: item.GetID(), itemType: itemTypeOf(item)})
 		m.loading = true
-
-		switch item.GetID() {
-		case "virtual-continue-watching":
-			return m, loadContinueWatching(m.client)
-		case "virtual-next-up":
-			return m, loadNextUp(m.client)
-		case "virtual-recently-added-movies":
-			return m, loadRecentlyAddedMovies(m.client)
-		case "virtual-recently-added-shows":
-			return m, loadRecentlyAddedShows(m.client)
-		case "virtual-recently-added-episodes":
-			return m, loadRecentlyAddedEpisodes(m.client)
-		default:
-			return m, loadItems(m.client, item.GetID(), true)
-		}
+		cmd := m.loadCurrentFolder()
+		return m, cmd
 	}
 
 	// Media file — play it
+	if itemTypeOf(item) == jellyfin.ItemTypeAudio {
+		return m.playTrackList()
+	}
 	if m.currentDetails != nil && m.currentDetails.HasResumePosition() {
-		m.currentPlayingItem = m.currentDetails
-		return m, tea.Batch(
-			playItem(m.client, item.GetID(), m.currentDetails.GetPlaybackPositionTicks()),
-			createDelayedProgressUpdateCmd(),
-		)
+		return m.playCurrent(item.GetID(), m.currentDetails.GetPlaybackPositionTicks())
 	}
-	m.currentPlayingItem = m.currentDetails
-	return m, tea.Batch(
-		playItem(m.client, item.GetID(), 0),
-		createDelayedProgressUpdateCmd(),
-	)
+	return m.playCurrent(item.GetID(), 0)
 }
 
 func (m model) goBack() (model, tea.Cmd) {
@@ -749,6 +991,7 @@ func (m model) goBack() (model, tea.Cmd) {
 		return m, tea.Quit
 	}
 
+	m.cancelRequests()
 	m.currentPath = m.currentPath[:len(m.currentPath)-1]
 
 	if len(m.currentPath) == 0 {
@@ -757,24 +1000,39 @@ func (m model) goBack() (model, tea.Cmd) {
 		return m, loadLibraries(m.client)
 	}
 
-	parentID := m.currentPath[len(m.currentPath)-1].id
 	m.loading = true
+	cmd := m.loadCurrentFolder()
+	return m, cmd
+}
 
+// loadCurrentFolder loads the items of the folder at the end of the current path.
+func (m *model) loadCurrentFolder() tea.Cmd {
+	parentID := m.currentPath[len(m.currentPath)-1].id
 	switch parentID {
 	case "virtual-continue-watching":
-		return m, loadContinueWatching(m.client)
+		return loadContinueWatching(m.client)
 	case "virtual-next-up":
-		return m, loadNextUp(m.client)
+		return loadNextUp(m.client)
 	case "virtual-recently-added-movies":
-		return m, loadRecentlyAddedMovies(m.client)
+		return loadRecentlyAddedMovies(m.client)
 	case "virtual-recently-added-shows":
-		return m, loadRecentlyAddedShows(m.client)
+		return loadRecentlyAddedShows(m.client)
 	case "virtual-recently-added-episodes":
-		return m, loadRecentlyAddedEpisodes(m.client)
+		return loadRecentlyAddedEpisodes(m.client)
 	case "offline-library":
-		return m, loadDownloadedContent(m.client)
+		return loadDownloadedContent(m.client)
+	case favoritesFolderID:
+		return loadFavoriteGroups(m.client)
+	case playlistsFolderID:
+		return loadPlaylists(m.client)
 	default:
-		return m, loadItems(m.client, parentID, true)
+		if itemType, ok := strings.CutPrefix(parentID, favoritesGroupPrefix); ok {
+			return loadFavorites(m.client, itemType)
+		}
+		if m.currentPath[len(m.currentPath)-1].itemType == jellyfin.ItemTypePlaylist {
+			return loadPlaylistEntries(m.client, parentID)
+		}
+		return loadItemsPage(m.listContext(), m.client, parentID, m.folderQuery(0))
 	}
 }
 
@@ -860,12 +1118,12 @@ func (m *model) updateViewportForBottom() {
 }
 
 func (m *model) applyFilter() {
-	if m.filter == FilterAll {
+	if m.filter == FilterAll && !m.itemFilter.active() {
 		m.items = m.allItems
 	} else {
 		m.items = nil
 		for _, item := range m.allItems {
-			if m.itemMatchesFilter(item) {
+			if m.itemMatchesFilter(item) && m.itemFilter.matches(item) {
 				m.items = append(m.items, item)
 			}
 		}
@@ -1016,7 +1274,7 @@ func (m *model) buildDownloadedIDCache() {
 		if err != nil || info.IsDir() {
 			return nil
 		}
-		if !strings.HasSuffix(strings.ToLower(path), ".mkv") {
+		if !jellyfin.IsMediaFile(path) {
 			return nil
 		}
 		relPath, err := filepath.Rel(downloadsDir, path)
@@ -1033,7 +1291,7 @@ func (m *model) buildDownloadedIDCache() {
 				m.downloadedParentIDs[fmt.Sprintf("season:%d", num)] = true
 			}
 		}
-		baseName := strings.TrimSuffix(info.Name(), ".mkv")
+		baseName := strings.TrimSuffix(info.Name(), filepath.Ext(info.Name()))
 		m.downloadedFilenames[strings.ToLower(baseName)] = true
 		return nil
 	})
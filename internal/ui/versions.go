@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Banh-Canh/jtui/pkg/jellyfin"
+)
+
+// versionAction is what the version picker does with the chosen version.
+type versionAction int
+
+const (
+	versionPlay versionAction = iota
+	versionDownload
+)
+
+// versionPickerState holds the state of the version picker (VersionView), shown before
+// playing or downloading an item with several media sources.
+type versionPickerState struct {
+	item         *jellyfin.DetailedItem
+	action       versionAction
+	startTicks   int64 // resume position when playing
+	cursor       int
+	previousView ViewType
+}
+
+// hasVersions reports whether the shown item has several versions to choose from. A
+// downloaded item plays its file, so there is nothing to choose.
+func (m model) hasVersions(action versionAction) bool {
+	d := m.currentDetails
+	if d == nil || len(d.MediaSources) < 2 || m.client.IsOfflineMode() || strings.HasPrefix(d.GetID(), "offline-") {
+		return false
+	}
+	if action == versionPlay {
+		if _, isLocal := m.client.Download.GetLocalVideoPath(d); isLocal {
+			return false
+		}
+	}
+	return true
+}
+
+func (m model) openVersionPicker(action versionAction, startTicks int64) model {
+	m.versionPicker = versionPickerState{
+		item:         m.currentDetails,
+		action:       action,
+		startTicks:   startTicks,
+		previousView: m.currentView,
+	}
+	m.currentView = VersionView
+	return m
+}
+
+func (m model) closeVersionPicker() model {
+	m.currentView = m.versionPicker.previousView
+	m.versionPicker = versionPickerState{}
+	return m
+}
+
+// playCurrent plays the item whose details are shown, asking which version to play
+// first when it has several.
+func (m model) playCurrent(itemID string, startTicks int64) (model, tea.Cmd) {
+	if m.hasVersions(versionPlay) {
+		return m.openVersionPicker(versionPlay, startTicks), nil
+	}
+	m.currentPlayingItem = m.currentDetails
+	return m, tea.Batch(
+		playItem(m.client, itemID, startTicks),
+		createDelayedProgressUpdateCmd(),
+	)
+}
+
+func (m model) handleVersionKey(msg tea.KeyMsg) (model, tea.Cmd) {
+	p := &m.versionPicker
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "q", "backspace":
+		return m.closeVersionPicker(), nil
+	case "up", "k":
+		if p.cursor > 0 {
+			p.cursor--
+		}
+	case "down", "j":
+		if p.cursor < len(p.item.MediaSources)-1 {
+			p.cursor++
+		}
+	case "enter", " ":
+		item, action, startTicks := p.item, p.action, p.startTicks
+		sourceID := item.MediaSources[p.cursor].ID
+		m = m.closeVersionPicker()
+		if action == versionDownload {
+			return m, downloadVideo(m.client, item, sourceID)
+		}
+		m.currentPlayingItem = item
+		return m, tea.Batch(
+			playItemSource(m.client, item.GetID(), sourceID, startTicks),
+			createDelayedProgressUpdateCmd(),
+		)
+	}
+	return m, nil
+}
+
+// formatBitrate formats a bitrate in bits per second, e.g. "25.3 Mbps".
+func formatBitrate(bitrate int64) string {
+	if bitrate >= 1_000_000 {
+		return fmt.Sprintf("%.1f Mbps", float64(bitrate)/1_000_000)
+	}
+	return fmt.Sprintf("%d kbps", bitrate/1000)
+}
+
+// sourceSummary describes a version of an item, e.g. "MKV · 4K · 25.3 Mbps · 41.2 GB".
+func sourceSummary(source jellyfin.MediaSourceInfo) string {
+	var parts []string
+	if source.Container != "" {
+		parts = append(parts, strings.ToUpper(source.Container))
+	}
+	if resolution := source.Resolution(); resolution != "" {
+		parts = append(parts, resolution)
+	}
+	if source.Bitrate > 0 {
+		parts = append(parts, formatBitrate(source.Bitrate))
+	}
+	if source.Size > 0 {
+		parts = append(parts, formatFileSize(source.Size))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// sourceLabel names a version of an item and describes it.
+func sourceLabel(source jellyfin.MediaSourceInfo) string {
+	summary := sourceSummary(source)
+	if source.Name == "" {
+		return summary
+	}
+	if summary == "" {
+		return source.Name
+	}
+	return source.Name + " — " + summary
+}
+
+func (m model) renderVersionPicker() string {
+	p := m.versionPicker
+	var b strings.Builder
+
+	verb := "play"
+	if p.action == versionDownload {
+		verb = "download"
+	}
+	b.WriteString(headerTitleStyle.Render("Choose the version to " + verb))
+	b.WriteString("\n")
+	b.WriteString(dimStyle.Render(p.item.GetName()))
+	b.WriteString("\n\n")
+
+	width := max(m.width-12, 20)
+	for i, source := range p.item.MediaSources {
+		row := fitWidth(sourceLabel(source), width)
+		if i == p.cursor {
+			b.WriteString(selectedStyle.Render(strings.TrimRight(row, " ")))
+		} else {
+			b.WriteString(itemStyle.Render(strings.TrimRight(row, " ")))
+		}
+		if i < len(p.item.MediaSources)-1 {
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(dimStyle.Render("↑↓/jk select • enter " + verb + " • esc back"))
+
+	box := loginBoxStyle.Render(b.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -14,11 +14,18 @@ var helpText = strings.Join([]string{
 	"↑↓/jk navigate",
 	"Enter open",
 	"Space play/pause",
+	"S shuffle",
 	"h back",
 	"d download",
-	"f filter",
+	"f/F filter",
+	"o sort",
 	"w watched",
+	"* favorite",
+	"+ playlist",
+	"c chapters",
 	"/ search",
+	"P profile",
+	"? all keys",
 	"q quit",
 }, " • ")
 
@@ -27,6 +34,33 @@ var helpText = strings.Join([]string{
 // ---------------------------------------------------------------------------
 
 func (m model) View() string {
+	if m.currentView == LoginView && m.err == nil {
+		return m.renderLogin()
+	}
+	if m.currentView == ProfileView && m.err == nil {
+		return m.renderProfilePicker()
+	}
+	if m.currentView == FilterView && m.err == nil {
+		return m.renderFilterPopup()
+	}
+	if m.currentView == PlaylistView && m.err == nil {
+		return m.renderPlaylistPicker()
+	}
+	if m.currentView == GuideView && m.err == nil {
+		return m.renderGuide()
+	}
+	if m.currentView == VersionView && m.err == nil {
+		return m.renderVersionPicker()
+	}
+	if m.currentView == ChapterView && m.err == nil {
+		return m.renderChapterPicker()
+	}
+	if m.currentView == SeekView && m.err == nil {
+		return m.renderSeekPrompt()
+	}
+	if m.currentView == KeysView && m.err == nil {
+		return m.renderKeysPage()
+	}
 	if m.err != nil {
 		return fmt.Sprintf(
 			"Error: %v\n\nPress 'q' to quit or 'ctrl+c' to exit.\nIf this persists, check ~/.config/jtui/jtui.log for details.",
@@ -86,6 +120,8 @@ func (m model) View() string {
 	if m.isVideoPlaying && m.currentPlayingItem != nil {
 		progressBar := m.renderProgressBar()
 		finalContent = lipgloss.JoinVertical(lipgloss.Left, header, content, progressBar, help)
+	} else if m.binge.next != nil {
+		finalContent = lipgloss.JoinVertical(lipgloss.Left, header, content, m.renderBingeCountdown(), help)
 	} else {
 		finalContent = lipgloss.JoinVertical(lipgloss.Left, header, content, help)
 	}
@@ -141,20 +177,29 @@ func (m model) renderItemList(width, height, viewport, viewportOffset int) strin
 		}
 	}
 
-	if m.filter != FilterAll {
+	if labels := m.filterLabels(); len(labels) > 0 {
 		filteredCount := len(m.items)
 		totalCount := len(m.allItems)
-		title += fmt.Sprintf(" [%s: %d/%d]", m.filter, filteredCount, totalCount)
+		title += fmt.Sprintf(" [%s: %d/%d]", strings.Join(labels, ", "), filteredCount, totalCount)
+	}
+	if m.hasMoreItems() && m.pageFailed {
+		title += fmt.Sprintf(" (%d of %d, failed to load more)", len(m.allItems), m.totalItems)
+	} else if m.hasMoreItems() {
+		title += fmt.Sprintf(" (%d of %d)", len(m.allItems), m.totalItems)
 	}
 
 	content.WriteString(titleStyle.Width(width - 4).Render(title))
 	content.WriteString("\n")
 
 	if len(m.items) == 0 {
-		if m.filter != FilterAll {
+		if m.filter != FilterAll && !m.itemFilter.active() {
 			content.WriteString(dimStyle.Render(fmt.Sprintf("No %s items", strings.ToLower(m.filter.String()))))
 			content.WriteString("\n")
 			content.WriteString(dimStyle.Render("Press 'f' to clear filter"))
+		} else if m.itemFilter.active() {
+			content.WriteString(dimStyle.Render("No items match the filter"))
+			content.WriteString("\n")
+			content.WriteString(dimStyle.Render("Press 'F' to change it"))
 		} else {
 			content.WriteString(dimStyle.Render("No items found"))
 		}
@@ -175,6 +220,9 @@ func (m model) renderItemList(width, height, viewport, viewportOffset int) strin
 	for i := start; i < end; i++ {
 		item := m.items[i]
 		itemText := item.GetName()
+		if d, ok := detailedOf(item); ok && d.IsAudio() {
+			itemText = trackLabel(d)
+		}
 		watchedIcon := m.itemIcon(item)
 
 		if item.GetIsFolder() {
@@ -209,10 +257,9 @@ func (m model) renderItemList(width, height, viewport, viewportOffset int) strin
 	return content.String()
 }
 
-// itemIcon returns the status icon string for an item in the list.
+// itemIcon returns the status icon string for an item in the list, followed by a
+// heart for favorites.
 func (m model) itemIcon(item jellyfin.Item) string {
-	isOfflineItem := strings.HasPrefix(item.GetID(), "offline-")
-
 	var detailedItem jellyfin.DetailedItem
 	switch di := item.(type) {
 	case jellyfin.DetailedItem:
@@ -221,6 +268,30 @@ func (m model) itemIcon(item jellyfin.Item) string {
 		detailedItem = *di
 	}
 
+	icon := m.statusIcon(item, detailedItem)
+	if detailedItem.UserData.IsFavorite {
+		icon += favoriteIcon
+	}
+	return icon
+}
+
+func (m model) statusIcon(item jellyfin.Item, detailedItem jellyfin.DetailedItem) string {
+	isOfflineItem := strings.HasPrefix(item.GetID(), "offline-")
+
+	switch detailedItem.Type {
+	case jellyfin.ItemTypeMusicArtist:
+		return " 🎤 "
+	case jellyfin.ItemTypeMusicAlbum:
+		return " 💿 "
+	case jellyfin.ItemTypePlaylist:
+		return " 📜 "
+	case jellyfin.ItemTypeAudio:
+		if isOfflineItem || m.client.IsOfflineMode() || m.itemDownloadCache[detailedItem.GetID()] {
+			return " 💾🎵 "
+		}
+		return " 🎵 "
+	}
+
 	if detailedItem.Type != "" {
 		if !item.GetIsFolder() {
 			if isOfflineItem || m.client.IsOfflineMode() {
@@ -370,6 +441,18 @@ func (m model) renderVirtualFolderDescription(width int) string {
 		return infoStyle.Render(
 			"Recently Added Episodes\n\nShows the latest episodes added to your library.\nPress Enter to browse recently added episodes.",
 		)
+	case favoritesFolderID:
+		return infoStyle.Render("Favorites\n\nShows the items you marked as favorite.\nPress * on any item to add or remove it.")
+	case liveTvFolderID:
+		return infoStyle.Render(
+			"Live TV\n\nShows what your channels air now and next, your recordings and scheduled recordings.\nPress Enter to open the guide.",
+		)
+	case playlistsFolderID:
+		return infoStyle.Render("Playlists\n\nShows your playlists.\nPress + on any item to add it to a playlist.")
+	}
+	if strings.HasPrefix(itemID, favoritesGroupPrefix) {
+		itemType := strings.TrimPrefix(itemID, favoritesGroupPrefix)
+		return infoStyle.Render(fmt.Sprintf("Favorite %s\n\nPress Enter to browse them.", itemTypeLabel(itemType)))
 	}
 	_ = width // used by callers to constrain; here the lipgloss style handles it
 	return dimStyle.Render("Select an item to view details")
@@ -402,7 +485,30 @@ func (m model) writeDetailFields(details *strings.Builder, width, maxLines, line
 		}
 	}
 
-	if seasonNum := m.currentDetails.GetSeasonNumber(); seasonNum > 0 {
+	if m.currentDetails.IsAudio() || m.currentDetails.Type == jellyfin.ItemTypeMusicAlbum {
+		if artist := m.currentDetails.GetArtist(); artist != "" {
+			write(infoStyle.Render(fmt.Sprintf("Artist: %s", truncate(artist, width-10))))
+			if linesUsed >= maxLines {
+				return linesUsed
+			}
+		}
+		if album := m.currentDetails.Album; album != "" && m.currentDetails.IsAudio() {
+			write(infoStyle.Render(fmt.Sprintf("Album: %s", truncate(album, width-9))))
+			if linesUsed >= maxLines {
+				return linesUsed
+			}
+		}
+		if track := m.currentDetails.IndexNumber; track > 0 && m.currentDetails.IsAudio() {
+			if disc := m.currentDetails.ParentIndexNumber; disc > 1 {
+				write(infoStyle.Render(fmt.Sprintf("Track: %d (disc %d)", track, disc)))
+			} else {
+				write(infoStyle.Render(fmt.Sprintf("Track: %d", track)))
+			}
+			if linesUsed >= maxLines {
+				return linesUsed
+			}
+		}
+	} else if seasonNum := m.currentDetails.GetSeasonNumber(); seasonNum > 0 {
 		if ep := m.currentDetails.GetEpisodeNumber(); ep > 0 {
 			write(infoStyle.Render(fmt.Sprintf("Episode: S%02dE%02d", seasonNum, ep)))
 		} else {
@@ -438,6 +544,36 @@ func (m model) writeDetailFields(details *strings.Builder, width, maxLines, line
 		}
 	}
 
+	// Versions, the downloaded one marked
+	if sources := m.currentDetails.MediaSources; len(sources) == 1 {
+		if summary := sourceSummary(sources[0]); summary != "" {
+			write(infoStyle.Render(fmt.Sprintf("Format: %s", truncate(summary, width-10))))
+		}
+	} else if len(sources) > 1 {
+		write(infoStyle.Render(fmt.Sprintf("Versions: %d", len(sources))))
+		for _, source := range sources {
+			if linesUsed >= maxLines {
+				return linesUsed
+			}
+			label := "  • " + sourceLabel(source)
+			if source.ID == m.currentDetails.DownloadedSourceID {
+				label += " 💾"
+			}
+			write(dimStyle.Render(strings.TrimRight(fitWidth(label, width-2), " ")))
+		}
+	}
+	if linesUsed >= maxLines {
+		return linesUsed
+	}
+
+	// Streams
+	for _, line := range streamLines(m.currentDetails) {
+		write(infoStyle.Render(strings.TrimRight(fitWidth(line, width-2), " ")))
+		if linesUsed >= maxLines {
+			return linesUsed
+		}
+	}
+
 	// Download status
 	linesUsed = m.writeDownloadStatus(details, maxLines, linesUsed)
 	if linesUsed >= maxLines {
@@ -454,6 +590,21 @@ func (m model) writeDetailFields(details *strings.Builder, width, maxLines, line
 			write(dimStyle.Render("  Enter to resume, Space to restart"))
 		}
 	}
+	if m.currentDetails.UserData.IsFavorite && linesUsed < maxLines {
+		write(infoStyle.Render("♥ Favorite"))
+	}
+
+	// Chapters, as many as fit
+	if chapters := m.currentDetails.Chapters; len(chapters) > 0 && linesUsed < maxLines {
+		write(infoStyle.Render(fmt.Sprintf("Chapters: %d (c to choose)", len(chapters))))
+		for i, chapter := range chapters {
+			if linesUsed >= maxLines {
+				break
+			}
+			row := fmt.Sprintf("  %s %s", formatSeconds(ticksToSeconds(chapter.StartPositionTicks)), chapterName(chapters, i))
+			write(dimStyle.Render(strings.TrimRight(fitWidth(row, width-2), " ")))
+		}
+	}
 
 	return linesUsed
 }
@@ -489,14 +640,18 @@ func (m model) writeDownloadStatus(details *strings.Builder, maxLines, linesUsed
 // Progress bar
 // ---------------------------------------------------------------------------
 
-func (m model) renderProgressBar() string {
-	if !m.isVideoPlaying || m.currentPlayingItem == nil {
-		return ""
-	}
-	if m.width < 30 {
-		return ""
-	}
+// progressLayout is how the progress line is laid out. It is shared by the rendering
+// and by mouse seeking, which needs to know where the bar is.
+type progressLayout struct {
+	prefix     string // title and position, up to the bar
+	trackInfo  string
+	totalTime  string
+	percentage float64
+	barStart   int // column of the first cell of the bar
+	barWidth   int
+}
 
+func (m model) progressLayout() progressLayout {
 	var percentage float64
 	if m.currentPlayDuration > 0 {
 		percentage = (m.currentPlayPosition / m.currentPlayDuration) * 100
@@ -523,6 +678,7 @@ func (m model) renderProgressBar() string {
 	} else {
 		trackInfo = fmt.Sprintf("🔊 %s • 💬 %s", currentAudio, currentSub)
 	}
+	trackInfo += m.controlsInfo()
 
 	videoTitle := m.currentPlayingItem.GetName()
 	usedSpace := len(currentTime) + len(totalTime) + len(trackInfo) + 35
@@ -546,36 +702,57 @@ func (m model) renderProgressBar() string {
 		barWidth = 8
 	}
 
-	filledWidth := int((percentage / 100) * float64(barWidth))
-	if filledWidth > barWidth {
-		filledWidth = barWidth
+	prefix := fmt.Sprintf("%s %s [", progressTitleStyle.Render("▶ "+videoTitle), progressTimeStyle.Render(currentTime))
+	return progressLayout{
+		prefix:     prefix,
+		trackInfo:  trackInfo,
+		totalTime:  totalTime,
+		percentage: percentage,
+		barStart:   lipgloss.Width(prefix) + 1, // after the padding of the bar
+		barWidth:   barWidth,
 	}
+}
 
+func (m model) renderProgressBar() string {
+	if !m.isVideoPlaying || m.currentPlayingItem == nil {
+		return ""
+	}
+	if m.width < 30 {
+		return ""
+	}
+
+	l := m.progressLayout()
+	filledWidth := int((l.percentage / 100) * float64(l.barWidth))
+	if filledWidth > l.barWidth {
+		filledWidth = l.barWidth
+	}
+
+	marks := chapterMarks(m.currentPlayingItem, m.currentPlayDuration, l.barWidth)
 	progressBar := ""
-	for i := 0; i < barWidth; i++ {
-		if i < filledWidth {
+	for i := 0; i < l.barWidth; i++ {
+		switch {
+		case marks[i]:
+			progressBar += "│"
+		case i < filledWidth:
 			progressBar += "█"
-		} else {
+		default:
 			progressBar += "░"
 		}
 	}
 
-	progressStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color("#7D56F4")).
-		Padding(0, 1)
-	titleSt := lipgloss.NewStyle().Foreground(lipgloss.Color("#FAFAFA")).Bold(true)
-	timeSt := lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA"))
-	trackSt := lipgloss.NewStyle().Foreground(lipgloss.Color("#88C999"))
-
-	progressLine := fmt.Sprintf("%s %s [%s] %s (%.1f%%)",
-		titleSt.Render("▶ "+videoTitle),
-		timeSt.Render(currentTime),
+	progressLine := fmt.Sprintf("%s%s] %s (%.1f%%)",
+		l.prefix,
 		progressStyle.Render(progressBar),
-		timeSt.Render(totalTime),
-		percentage,
+		progressTimeStyle.Render(l.totalTime),
+		l.percentage,
 	)
-	trackLine := trackSt.Render(trackInfo)
+	trackLine := progressTrackStyle.Render(l.trackInfo)
+	if prompt := m.segmentPrompt(); prompt != "" {
+		trackLine += "  " + selectedStyle.Render(prompt)
+	}
+	if info := m.queueFillInfo(); info != "" {
+		trackLine += "  " + dimStyle.Render(info)
+	}
 
 	return progressLine + "\n" + trackLine
 }
@@ -589,10 +766,15 @@ func (m model) renderHeader() string {
 		return "JTUI"
 	}
 	appName := headerTitleStyle.Render("󰚯 JTUI")
+	if profile := m.client.GetConfig().Profile; profile != "" {
+		appName += headerDividerStyle.Render(" │ ") + headerStatusStyle.Render("󰒋 "+profile)
+	}
 
 	var status string
 	if m.client.IsOfflineMode() {
 		status = headerOfflineStyle.Render("󰪎 OFFLINE")
+	} else if m.client.IsDegraded() {
+		status = headerDegradedStyle.Render("󰀦 DEGRADED")
 	} else {
 		status = headerStatusStyle.Render("󰈀 ONLINE")
 	}
@@ -621,25 +803,29 @@ func (m model) renderHeader() string {
 		status = headerStatusStyle.Render(dlInfo)
 	}
 
+	var prefix string
+	if labels := m.filterLabels(); len(labels) > 0 {
+		prefix = fmt.Sprintf("[filter: %s] ", strings.Join(labels, ", "))
+	}
+	if sort := m.currentSort(); sort != SortDefault && len(m.currentPath) > 0 {
+		prefix += fmt.Sprintf("[sort: %s] ", sort)
+	}
+
 	var currentLocation string
 	switch m.currentView {
 	case LibraryView:
 		if len(m.currentPath) == 0 {
-			currentLocation = "󰉕 Libraries"
+			currentLocation = prefix + "󰉕 Libraries"
 		} else {
-			currentLocation = "󰉖 " + m.currentPath[len(m.currentPath)-1].name
+			currentLocation = prefix + "󰉖 " + m.currentPath[len(m.currentPath)-1].name
 		}
 	case SearchView:
 		currentLocation = "󰍉 Search: " + m.searchQuery
 	default:
-		var filterPrefix string
-		if m.filter != FilterAll {
-			filterPrefix = fmt.Sprintf("[filter: %s] ", m.filter)
-		}
 		if len(m.currentPath) > 0 {
-			currentLocation = filterPrefix + "󰉖 " + m.currentPath[len(m.currentPath)-1].name
+			currentLocation = prefix + "󰉖 " + m.currentPath[len(m.currentPath)-1].name
 		} else {
-			currentLocation = filterPrefix + "󰉕 Content"
+			currentLocation = prefix + "󰉕 Content"
 		}
 	}
 
@@ -1,8 +1,10 @@
 package jellyfin
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,7 +13,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/adrg/xdg"
+	"golang.org/x/term"
 )
 
 // AuthAPI handles authentication-related operations
@@ -19,6 +21,18 @@ type AuthAPI struct {
 	client *Client
 }
 
+const (
+	// QuickConnectTimeout is how long a Quick Connect code is waited on before it is considered expired
+	QuickConnectTimeout = 60 * time.Second
+	// QuickConnectPollInterval is the delay between Quick Connect status checks
+	QuickConnectPollInterval = 2 * time.Second
+)
+
+// ErrQuickConnectDisabled is returned when the server has Quick Connect turned off
+var ErrQuickConnectDisabled = errors.New(
+	"Quick Connect is not enabled on this server, enable it in Dashboard > General > Quick Connect",
+)
+
 // TestConnection tests basic connectivity to the Jellyfin server
 func (a *AuthAPI) TestConnection() error {
 	resp, err := a.client.http.Get(a.client.config.ServerURL + "/System/Info")
@@ -31,6 +45,8 @@ func (a *AuthAPI) TestConnection() error {
 		return fmt.Errorf("server returned HTTP %d", resp.StatusCode)
 	}
 
+	// The server answers again, stop pausing requests to it
+	a.client.breaker.record(false)
 	return nil
 }
 
@@ -67,10 +83,7 @@ func (a *AuthAPI) CheckQuickConnectEnabled() (bool, error) {
 func (a *AuthAPI) InitiateQuickConnect() (*QuickConnectData, error) {
 	url := fmt.Sprintf("%s/QuickConnect/Initiate", a.client.config.ServerURL)
 
-	// Generate deviceId if not set
-	if a.client.config.DeviceID == "" {
-		a.client.config.DeviceID = fmt.Sprintf("%s-%d", a.client.config.ClientName, time.Now().Unix())
-	}
+	a.EnsureDeviceID()
 
 	// Try optimized method order (most likely to succeed first)
 	methods := []struct {
@@ -95,6 +108,14 @@ func (a *AuthAPI) InitiateQuickConnect() (*QuickConnectData, error) {
 	return nil, fmt.Errorf("all Quick Connect initiation methods failed, last error: %w", lastErr)
 }
 
+// EnsureDeviceID generates a device ID if one is not set yet. Callers sending login
+// requests concurrently with other users of the client call it beforehand.
+func (a *AuthAPI) EnsureDeviceID() {
+	if a.client.config.DeviceID == "" {
+		a.client.config.DeviceID = fmt.Sprintf("%s-%d", a.client.config.ClientName, time.Now().Unix())
+	}
+}
+
 // tryQuickConnectMethod attempts Quick Connect initiation with a specific HTTP method
 func (a *AuthAPI) tryQuickConnectMethod(method, url string, body []byte) (*QuickConnectData, error) {
 	var reqBody io.Reader
@@ -233,56 +254,230 @@ func (a *AuthAPI) CompleteQuickConnect(secret string) (string, string, error) {
 	return result.AccessToken, result.User.ID, nil
 }
 
-// AuthenticateWithQuickConnect performs the complete Quick Connect authentication flow
-func (a *AuthAPI) AuthenticateWithQuickConnect() error {
+// StartQuickConnect checks that Quick Connect is enabled and initiates a new session.
+// The returned data carries the code to show to the user and when it expires.
+func (a *AuthAPI) StartQuickConnect() (*QuickConnectData, error) {
 	enabled, err := a.CheckQuickConnectEnabled()
 	if err != nil {
-		return fmt.Errorf("failed to check Quick Connect status: %w", err)
+		return nil, fmt.Errorf("failed to check Quick Connect status: %w", err)
 	}
 
 	if !enabled {
-		return fmt.Errorf("Quick Connect is not enabled on this server, enable it in Dashboard > General > Quick Connect")
+		return nil, ErrQuickConnectDisabled
 	}
 
 	quickConnectData, err := a.InitiateQuickConnect()
 	if err != nil {
-		return fmt.Errorf("Quick Connect initiation failed: %w", err)
+		return nil, fmt.Errorf("Quick Connect initiation failed: %w", err)
+	}
+
+	quickConnectData.ExpiresAt = time.Now().Add(QuickConnectTimeout)
+	return quickConnectData, nil
+}
+
+// QuickConnectCredentials checks once whether a Quick Connect session has been
+// approved. On approval it completes the exchange and returns the credentials without
+// storing them on the client; the access token is empty while the code is pending.
+func (a *AuthAPI) QuickConnectCredentials(data *QuickConnectData) (accessToken, userID string, err error) {
+	authenticated, err := a.CheckQuickConnectStatus(data.Secret)
+	if err != nil || !authenticated {
+		return "", "", err
+	}
+
+	accessToken, userID, err = a.CompleteQuickConnect(data.Secret)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to complete Quick Connect: %w", err)
+	}
+	return accessToken, userID, nil
+}
+
+// PollQuickConnect checks once whether a Quick Connect session has been approved.
+// On approval it completes the exchange and stores the credentials on the client.
+func (a *AuthAPI) PollQuickConnect(data *QuickConnectData) (bool, error) {
+	accessToken, userID, err := a.QuickConnectCredentials(data)
+	if err != nil || accessToken == "" {
+		return false, err
+	}
+
+	a.client.config.AccessToken = accessToken
+	a.client.config.UserID = userID
+
+	return true, nil
+}
+
+// AuthenticateWithQuickConnect performs the complete Quick Connect authentication flow
+// on the terminal, blocking until the code is approved or expires
+func (a *AuthAPI) AuthenticateWithQuickConnect() error {
+	quickConnectData, err := a.StartQuickConnect()
+	if err != nil {
+		return err
 	}
 
 	fmt.Printf(
-		"\nPlease enter this code in your Jellyfin app:\n\n    CODE: %s\n\nWaiting for approval (60 second timeout)...\n",
-		quickConnectData.Code,
+		"\nPlease enter this code in your Jellyfin app:\n\n    CODE: %s\n\nWaiting for approval (%s timeout)...\n",
+		quickConnectData.Code, QuickConnectTimeout,
 	)
 
-	timeout := time.Now().Add(60 * time.Second)
-	for time.Now().Before(timeout) {
-		authenticated, err := a.CheckQuickConnectStatus(quickConnectData.Secret)
+	for time.Now().Before(quickConnectData.ExpiresAt) {
+		authenticated, err := a.PollQuickConnect(quickConnectData)
 		if err != nil {
-			time.Sleep(2 * time.Second)
+			time.Sleep(QuickConnectPollInterval)
 			continue
 		}
 
 		if authenticated {
-			accessToken, userID, err := a.CompleteQuickConnect(quickConnectData.Secret)
-			if err != nil {
-				return fmt.Errorf("failed to complete Quick Connect: %w", err)
-			}
+			return nil
+		}
 
-			a.client.config.AccessToken = accessToken
-			a.client.config.UserID = userID
+		time.Sleep(QuickConnectPollInterval)
+	}
 
-			return nil
+	return fmt.Errorf("Quick Connect authentication timed out after %s", QuickConnectTimeout)
+}
+
+// AuthenticateByName authenticates with a username and password.
+// The password is only sent to the server and is never persisted.
+func (a *AuthAPI) AuthenticateByName(username, password string) error {
+	a.EnsureDeviceID()
+
+	accessToken, userID, err := a.PasswordCredentials(username, password)
+	if err != nil {
+		return err
+	}
+
+	a.client.config.AccessToken = accessToken
+	a.client.config.UserID = userID
+
+	return nil
+}
+
+// PasswordCredentials authenticates with a username and password and returns the
+// credentials without storing them on the client
+func (a *AuthAPI) PasswordCredentials(username, password string) (accessToken, userID string, err error) {
+	if username == "" {
+		return "", "", fmt.Errorf("username cannot be empty")
+	}
+
+	url := fmt.Sprintf("%s/Users/AuthenticateByName", a.client.config.ServerURL)
+
+	jsonBody, err := json.Marshal(map[string]string{
+		"Username": username,
+		"Pw":       password,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s", a.client.config.ClientName, a.client.config.Version))
+	req.Header.Set("Authorization", a.client.GetAuthHeader())
+
+	resp, err := a.client.http.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return "", "", fmt.Errorf("invalid username or password")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("server returned HTTP %d", resp.StatusCode)
+	}
+
+	var result AuthenticationResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if result.AccessToken == "" {
+		return "", "", fmt.Errorf("no access token in response")
+	}
+
+	if result.User.ID == "" {
+		return "", "", fmt.Errorf("no user ID in response")
+	}
+
+	return result.AccessToken, result.User.ID, nil
+}
+
+// AuthenticateWithPassword prompts for credentials on the terminal and performs
+// the username/password authentication flow
+func (a *AuthAPI) AuthenticateWithPassword() error {
+	username, password, err := PromptCredentials(a.client.config.Username)
+	if err != nil {
+		return err
+	}
+
+	if err := a.AuthenticateByName(username, password); err != nil {
+		return fmt.Errorf("password authentication failed: %w", err)
+	}
+
+	return nil
+}
+
+// PromptCredentials asks for a username (unless one is given) and a password.
+// The password is read without echoing it to the terminal.
+func PromptCredentials(username string) (string, string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", "", fmt.Errorf("password login requires an interactive terminal")
+	}
+
+	if username == "" {
+		fmt.Print("Username: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read username: %w", err)
 		}
+		username = strings.TrimSpace(line)
+		if username == "" {
+			return "", "", fmt.Errorf("username cannot be empty")
+		}
+	}
+
+	fmt.Printf("Password for %s: ", username)
+	password, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return username, string(password), nil
+}
+
+// Authenticate runs the configured authentication method. Without an explicit
+// method, Quick Connect is used when the server has it enabled and the user is
+// prompted for a username and password otherwise.
+func (a *AuthAPI) Authenticate() error {
+	switch a.client.config.AuthMethod {
+	case AuthMethodQuickConnect:
+		return a.AuthenticateWithQuickConnect()
+	case AuthMethodPassword:
+		return a.AuthenticateWithPassword()
+	}
 
-		time.Sleep(2 * time.Second)
+	if enabled, err := a.CheckQuickConnectEnabled(); err == nil && enabled {
+		return a.AuthenticateWithQuickConnect()
 	}
 
-	return fmt.Errorf("Quick Connect authentication timed out after 60 seconds")
+	return a.AuthenticateWithPassword()
 }
 
 // ValidateSession validates the current authentication session
 func (a *AuthAPI) ValidateSession() error {
-	if a.client.config.AccessToken == "" {
+	return a.validateToken(a.client.config.AccessToken)
+}
+
+// validateToken checks that the server accepts an access token
+func (a *AuthAPI) validateToken(accessToken string) error {
+	if accessToken == "" {
 		return fmt.Errorf("no access token available")
 	}
 
@@ -293,7 +488,7 @@ func (a *AuthAPI) ValidateSession() error {
 		return fmt.Errorf("failed to create validation request: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", a.client.config.AccessToken))
+	req.Header.Set("Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", accessToken))
 	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s", a.client.config.ClientName, a.client.config.Version))
 
 	resp, err := a.client.http.Do(req)
@@ -303,7 +498,7 @@ func (a *AuthAPI) ValidateSession() error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode == http.StatusUnauthorized {
-		return fmt.Errorf("session expired")
+		return fmt.Errorf("session expired: %w", ErrUnauthorized)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -315,31 +510,61 @@ func (a *AuthAPI) ValidateSession() error {
 
 // LoadSession loads a saved authentication session from disk
 func (a *AuthAPI) LoadSession() error {
-	sessionFile := filepath.Join(xdg.CacheHome, a.client.config.ClientName, "session.txt")
+	sessionData, err := a.readSession()
+	if err != nil {
+		return err
+	}
+
+	a.client.config.AccessToken = sessionData.AccessToken
+	a.client.config.UserID = sessionData.UserID
+	if sessionData.UserID == "" {
+		// Need to get userID by validating
+		if err := a.validateAndUpdateSession(); err != nil {
+			return fmt.Errorf("failed to validate session and get userID: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// readSession reads the saved session without applying it. Sessions saved in the old
+// format only hold the token and have no user ID.
+func (a *AuthAPI) readSession() (SessionData, error) {
+	sessionFile := a.sessionFilePath()
 	if _, err := os.Stat(sessionFile); os.IsNotExist(err) {
-		return fmt.Errorf("no session file found")
+		return SessionData{}, fmt.Errorf("no session file found")
 	}
 
 	content, err := os.ReadFile(sessionFile)
 	if err != nil {
-		return fmt.Errorf("failed to read session file: %w", err)
+		return SessionData{}, fmt.Errorf("failed to read session file: %w", err)
 	}
 
 	// Try to parse as JSON for new format
 	var sessionData SessionData
-	if err := json.Unmarshal(content, &sessionData); err == nil {
-		a.client.config.AccessToken = sessionData.AccessToken
-		a.client.config.UserID = sessionData.UserID
-	} else {
+	if err := json.Unmarshal(content, &sessionData); err != nil {
 		// Old format - just the token
-		a.client.config.AccessToken = strings.TrimSpace(string(content))
-		// Need to get userID by validating
-		if err := a.validateAndUpdateSession(); err != nil {
-			return fmt.Errorf("failed to validate session and get userID: %w", err)
-		}
+		sessionData = SessionData{AccessToken: strings.TrimSpace(string(content))}
 	}
+	return sessionData, nil
+}
 
-	return nil
+// restoreSession returns the saved session if the server still accepts it, nil
+// otherwise. Unlike LoadSession it leaves the client untouched.
+func (a *AuthAPI) restoreSession() *SessionData {
+	sessionData, err := a.readSession()
+	if err != nil {
+		return nil
+	}
+	if sessionData.UserID == "" {
+		if sessionData.UserID, err = a.fetchUserID(sessionData.AccessToken); err != nil {
+			return nil
+		}
+	}
+	if err := a.validateToken(sessionData.AccessToken); err != nil {
+		return nil
+	}
+	return &sessionData
 }
 
 // SaveSession saves the current authentication session to disk
@@ -348,8 +573,8 @@ func (a *AuthAPI) SaveSession() error {
 		return fmt.Errorf("no complete session data to save")
 	}
 
-	sessionDir := filepath.Join(xdg.CacheHome, a.client.config.ClientName)
-	if err := os.MkdirAll(sessionDir, 0o700); err != nil {
+	sessionFile := a.sessionFilePath()
+	if err := os.MkdirAll(filepath.Dir(sessionFile), 0o700); err != nil {
 		return fmt.Errorf("failed to create session directory: %w", err)
 	}
 
@@ -363,37 +588,51 @@ func (a *AuthAPI) SaveSession() error {
 		return fmt.Errorf("failed to marshal session data: %w", err)
 	}
 
-	sessionFile := filepath.Join(sessionDir, "session.txt")
 	return os.WriteFile(sessionFile, jsonData, 0o600)
 }
 
+// sessionFilePath returns where the session is persisted. Named profiles get their
+// own file so logging into one server never replaces the session of another.
+func (a *AuthAPI) sessionFilePath() string {
+	return filepath.Join(a.client.CacheDir(), "session.txt")
+}
+
 // validateAndUpdateSession validates old sessions and gets the user ID
 func (a *AuthAPI) validateAndUpdateSession() error {
+	userID, err := a.fetchUserID(a.client.config.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	a.client.config.UserID = userID
+	return a.SaveSession()
+}
+
+// fetchUserID returns the user an access token belongs to
+func (a *AuthAPI) fetchUserID(accessToken string) (string, error) {
 	url := fmt.Sprintf("%s/Users/Me", a.client.config.ServerURL)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create user info request: %w", err)
+		return "", fmt.Errorf("failed to create user info request: %w", err)
 	}
 
-	req.Header.Set("X-Emby-Token", a.client.config.AccessToken)
+	req.Header.Set("X-Emby-Token", accessToken)
 	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s", a.client.config.ClientName, a.client.config.Version))
 
 	resp, err := a.client.http.Do(req)
 	if err != nil {
-		return fmt.Errorf("user info request failed: %w", err)
+		return "", fmt.Errorf("user info request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to get user info: HTTP %d", resp.StatusCode)
+		return "", fmt.Errorf("failed to get user info: HTTP %d", resp.StatusCode)
 	}
 
 	var userInfo UserInfo
 	if err := json.NewDecoder(resp.Body).Decode(&userInfo); err != nil {
-		return fmt.Errorf("failed to decode user info: %w", err)
+		return "", fmt.Errorf("failed to decode user info: %w", err)
 	}
-
-	a.client.config.UserID = userInfo.ID
-	return a.SaveSession()
+	return userInfo.ID, nil
 }
@@ -2,9 +2,15 @@ package jellyfin
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
+// validProfileRe restricts profile names to characters that are safe in paths
+var validProfileRe = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
 // ClientBuilder provides a fluent interface for creating Jellyfin clients
 type ClientBuilder struct {
 	config *Config
@@ -45,12 +51,73 @@ func (b *ClientBuilder) WithTimeout(timeout time.Duration) *ClientBuilder {
 	return b
 }
 
+// WithRetryPolicy sets how idempotent GET requests are retried after transient failures
+func (b *ClientBuilder) WithRetryPolicy(policy RetryPolicy) *ClientBuilder {
+	b.config.Retry = policy
+	return b
+}
+
+// WithCircuitBreaker sets after how many consecutive failures requests to the server are
+// paused, and for how long
+func (b *ClientBuilder) WithCircuitBreaker(threshold int, cooldown time.Duration) *ClientBuilder {
+	b.config.BreakerThreshold = threshold
+	b.config.BreakerCooldown = cooldown
+	return b
+}
+
+// WithMaxBitrate sets the highest bitrate, in bits per second, streamed without
+// transcoding (0 for no limit)
+func (b *ClientBuilder) WithMaxBitrate(bitsPerSecond int64) *ClientBuilder {
+	b.config.MaxBitrate = bitsPerSecond
+	return b
+}
+
+// WithLanguages sets the audio and subtitle languages to play, most preferred first
+func (b *ClientBuilder) WithLanguages(audio, subtitle []string) *ClientBuilder {
+	b.config.AudioLanguages = audio
+	b.config.SubtitleLanguages = subtitle
+	return b
+}
+
+// WithSegmentAction sets what to do when playback enters a type of media segment
+func (b *ClientBuilder) WithSegmentAction(segmentType, action string) *ClientBuilder {
+	if b.config.SegmentActions == nil {
+		b.config.SegmentActions = make(map[string]string)
+	}
+	b.config.SegmentActions[segmentType] = action
+	return b
+}
+
 // WithDeviceID sets the device ID
 func (b *ClientBuilder) WithDeviceID(deviceID string) *ClientBuilder {
 	b.config.DeviceID = deviceID
 	return b
 }
 
+// WithAuthMethod sets the authentication method (quickconnect, password, or empty to auto-detect)
+func (b *ClientBuilder) WithAuthMethod(method string) *ClientBuilder {
+	b.config.AuthMethod = method
+	return b
+}
+
+// WithUsername sets the username used for password authentication
+func (b *ClientBuilder) WithUsername(username string) *ClientBuilder {
+	b.config.Username = username
+	return b
+}
+
+// WithProfile sets the server profile name used to separate sessions and downloads
+func (b *ClientBuilder) WithProfile(profile string) *ClientBuilder {
+	b.config.Profile = profile
+	return b
+}
+
+// WithDownloadsDir overrides the directory downloads are stored in
+func (b *ClientBuilder) WithDownloadsDir(dir string) *ClientBuilder {
+	b.config.DownloadsDir = dir
+	return b
+}
+
 // WithCredentials sets the access token and user ID
 func (b *ClientBuilder) WithCredentials(accessToken, userID string) *ClientBuilder {
 	b.config.AccessToken = accessToken
@@ -64,11 +131,25 @@ func (b *ClientBuilder) Build() (*Client, error) {
 		return nil, fmt.Errorf("server URL is required")
 	}
 
+	if b.config.Profile != "" && !validProfileRe.MatchString(b.config.Profile) {
+		return nil, fmt.Errorf("invalid profile name %q (use letters, digits, '-' and '_')", b.config.Profile)
+	}
+
+	switch b.config.AuthMethod {
+	case "", AuthMethodQuickConnect, AuthMethodPassword:
+	default:
+		return nil, fmt.Errorf("unknown auth method %q (expected %q or %q)",
+			b.config.AuthMethod, AuthMethodQuickConnect, AuthMethodPassword)
+	}
+
 	return NewClient(b.config), nil
 }
 
-// BuildAndConnect creates the client and performs authentication if needed
-func (b *ClientBuilder) BuildAndConnect() (*Client, error) {
+// BuildAndRestore creates the client, tests the connection and restores a saved
+// session if it is still valid. It never prompts: when no valid session exists the
+// returned client is unauthenticated and the caller drives the login itself
+// (see AuthAPI.StartQuickConnect and AuthAPI.AuthenticateByName).
+func (b *ClientBuilder) BuildAndRestore() (*Client, error) {
 	client, err := b.Build()
 	if err != nil {
 		return nil, err
@@ -79,18 +160,34 @@ func (b *ClientBuilder) BuildAndConnect() (*Client, error) {
 		return nil, fmt.Errorf("failed to connect to server: %w", err)
 	}
 
-	// If not authenticated, try to load session or authenticate
+	// If not authenticated, try to load and validate an existing session
 	if !client.IsAuthenticated() {
-		// Try to load existing session
 		if err := client.Auth.LoadSession(); err == nil {
-			// Validate existing session
 			if err := client.Auth.ValidateSession(); err == nil {
 				return client, nil
 			}
 		}
 
-		// Authenticate using Quick Connect
-		if err := client.Auth.AuthenticateWithQuickConnect(); err != nil {
+		// Drop whatever the stale session left behind
+		client.config.AccessToken = ""
+		client.config.UserID = ""
+	}
+
+	return client, nil
+}
+
+// BuildAndConnect creates the client and performs authentication if needed.
+// If the session expires later, the client logs in again the same way.
+func (b *ClientBuilder) BuildAndConnect() (*Client, error) {
+	client, err := b.BuildAndRestore()
+	if err != nil {
+		return nil, err
+	}
+	client.SetReauthFunc(InteractiveReauth)
+
+	if !client.IsAuthenticated() {
+		// Authenticate using the configured method
+		if err := client.Auth.Authenticate(); err != nil {
 			return nil, fmt.Errorf("authentication failed: %w", err)
 		}
 
@@ -101,21 +198,149 @@ func (b *ClientBuilder) BuildAndConnect() (*Client, error) {
 	return client, nil
 }
 
-// ConnectFromConfig creates a client from external configuration (like viper)
-// Falls back to offline mode if server is unavailable
-func ConnectFromConfig(getConfigString func(key string) string) (*Client, error) {
+// builderFromConfig creates a client builder from external configuration (like viper)
+func builderFromConfig(getConfigString func(key string) string) (*ClientBuilder, error) {
 	serverURL := getConfigString("jellyfin.server_url")
 	if serverURL == "" {
 		return nil, fmt.Errorf("jellyfin.server_url must be configured")
 	}
 
-	// Try to connect normally first
-	client, err := NewClientBuilder().
+	builder := NewClientBuilder().
 		WithServerURL(serverURL).
-		BuildAndConnect()
+		WithAuthMethod(getConfigString("jellyfin.auth_method")).
+		WithUsername(getConfigString("jellyfin.username")).
+		WithProfile(getConfigString("jellyfin.profile")).
+		WithDownloadsDir(getConfigString("jellyfin.downloads_dir"))
+
+	if err := applyNetworkConfig(builder, getConfigString); err != nil {
+		return nil, err
+	}
+	if value := getConfigString("jellyfin.max_bitrate"); value != "" {
+		bitrate, err := ParseBitrate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jellyfin.max_bitrate %q (expected bits per second like 4000000, 1500k or 8M)", value)
+		}
+		builder.WithMaxBitrate(bitrate)
+	}
+	builder.WithLanguages(
+		ParseLanguages(getConfigString("jellyfin.audio_languages")),
+		ParseLanguages(getConfigString("jellyfin.subtitle_languages")),
+	)
+	for _, segmentType := range SegmentTypes {
+		key := "jellyfin.segments." + strings.ToLower(segmentType)
+		if value := getConfigString(key); value != "" {
+			action, err := ParseSegmentAction(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			builder.WithSegmentAction(segmentType, action)
+		}
+	}
+	return builder, nil
+}
+
+// ParseLanguages parses a comma-separated list of languages, e.g. "jpn, eng"
+func ParseLanguages(value string) []string {
+	var languages []string
+	for language := range strings.SplitSeq(value, ",") {
+		if language = strings.TrimSpace(language); language != "" {
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+// ParseBitrate parses a bitrate in bits per second, with an optional k or M suffix
+func ParseBitrate(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	multiplier := int64(1)
+	switch {
+	case strings.HasSuffix(value, "k"), strings.HasSuffix(value, "K"):
+		multiplier = 1_000
+	case strings.HasSuffix(value, "M"), strings.HasSuffix(value, "m"):
+		multiplier = 1_000_000
+	}
+	if multiplier > 1 {
+		value = value[:len(value)-1]
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid bitrate %q", value)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// applyNetworkConfig reads the optional timeout, retry and circuit breaker settings
+func applyNetworkConfig(builder *ClientBuilder, getConfigString func(key string) string) error {
+	duration := func(key string, dest *time.Duration) error {
+		if value := getConfigString(key); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				return fmt.Errorf("invalid %s %q (expected a duration like 500ms or 10s)", key, value)
+			}
+			*dest = d
+		}
+		return nil
+	}
+	count := func(key string, dest *int) error {
+		if value := getConfigString(key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid %s %q (expected a positive number)", key, value)
+			}
+			*dest = n
+		}
+		return nil
+	}
+
+	config := builder.config
+	config.Retry = DefaultRetryPolicy()
+	for _, err := range []error{
+		duration("jellyfin.timeout", &config.Timeout),
+		count("jellyfin.retry.max_attempts", &config.Retry.MaxAttempts),
+		duration("jellyfin.retry.base_delay", &config.Retry.BaseDelay),
+		duration("jellyfin.retry.max_delay", &config.Retry.MaxDelay),
+		count("jellyfin.circuit_breaker.threshold", &config.BreakerThreshold),
+		duration("jellyfin.circuit_breaker.cooldown", &config.BreakerCooldown),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConnectFromConfig creates a client from external configuration (like viper)
+// Falls back to offline mode if server is unavailable
+func ConnectFromConfig(getConfigString func(key string) string) (*Client, error) {
+	builder, err := builderFromConfig(getConfigString)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to connect normally first
+	client, err := builder.BuildAndConnect()
 	if err != nil {
 		// If server connection fails, try offline mode
-		return CreateOfflineClient(serverURL)
+		return createOfflineClient(builder.config)
+	}
+
+	return client, nil
+}
+
+// PrepareFromConfig is like ConnectFromConfig but does not block on interactive
+// authentication. The returned client is either authenticated from a saved session,
+// in offline mode, or unauthenticated and waiting for the caller to log in.
+func PrepareFromConfig(getConfigString func(key string) string) (*Client, error) {
+	builder, err := builderFromConfig(getConfigString)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := builder.BuildAndRestore()
+	if err != nil {
+		// If server connection fails, try offline mode
+		return createOfflineClient(builder.config)
 	}
 
 	return client, nil
@@ -123,15 +348,32 @@ func ConnectFromConfig(getConfigString func(key string) string) (*Client, error)
 
 // CreateOfflineClient creates a client that works only with offline content
 func CreateOfflineClient(serverURL string) (*Client, error) {
+	return createOfflineClient(&Config{ServerURL: serverURL})
+}
+
+// createOfflineClient creates an offline client that keeps the profile settings of base
+func createOfflineClient(base *Config) (*Client, error) {
 	config := &Config{
-		ServerURL:  serverURL,
-		ClientName: "jtui-offline",
-		Version:    "1.0.0",
-		Timeout:    10 * time.Second,
-		// No AccessToken or UserID - indicates offline mode
+		ServerURL:         base.ServerURL,
+		ClientName:        base.ClientName,
+		Version:           base.Version,
+		Timeout:           base.Timeout,
+		AuthMethod:        base.AuthMethod,
+		Username:          base.Username,
+		Profile:           base.Profile,
+		DownloadsDir:      base.DownloadsDir,
+		Retry:             base.Retry,
+		BreakerThreshold:  base.BreakerThreshold,
+		BreakerCooldown:   base.BreakerCooldown,
+		MaxBitrate:        base.MaxBitrate,
+		AudioLanguages:    base.AudioLanguages,
+		SubtitleLanguages: base.SubtitleLanguages,
+		SegmentActions:    base.SegmentActions,
+		// No AccessToken or UserID until GoOnline restores the saved session
 	}
 
 	client := NewClient(config)
+	client.offline.Store(true)
 
 	// Check if we have any offline content
 	offlineItems, err := client.Download.DiscoverOfflineContent()
@@ -148,5 +390,10 @@ func CreateOfflineClient(serverURL string) (*Client, error) {
 
 // IsOfflineMode checks if the client is running in offline mode
 func (c *Client) IsOfflineMode() bool {
-	return c.config.AccessToken == "" && c.config.UserID == ""
+	return c.offline.Load()
+}
+
+// NeedsLogin reports whether the client is connected to a server but has no credentials yet
+func (c *Client) NeedsLogin() bool {
+	return !c.IsOfflineMode() && !c.IsAuthenticated()
 }
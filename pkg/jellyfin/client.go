@@ -2,17 +2,30 @@
 package jellyfin
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/adrg/xdg"
 )
 
 // Client is the main Jellyfin API client
 type Client struct {
-	config *Config
-	http   *http.Client
+	config  *Config
+	http    *http.Client
+	offline atomic.Bool // set by CreateOfflineClient and GoOffline, cleared by GoOnline
+
+	reauth   ReauthFunc // runs when the server rejects the token, see SetReauthFunc
+	reauthMu sync.Mutex // serializes re-authentication between concurrent requests
+	breaker  *circuitBreaker
 
 	// API modules
 	Auth      *AuthAPI
@@ -21,6 +34,8 @@ type Client struct {
 	Playback  *PlaybackAPI
 	Search    *SearchAPI
 	Download  *DownloadAPI
+	Playlists *PlaylistsAPI
+	LiveTv    *LiveTvAPI
 }
 
 // Config holds the client configuration
@@ -32,8 +47,36 @@ type Config struct {
 	ClientName  string
 	Version     string
 	Timeout     time.Duration
+	AuthMethod  string // one of the AuthMethod constants, empty to auto-detect
+	Username    string // username for password login, prompted for if empty
+	// Profile names the server profile; each profile keeps its own session and downloads.
+	// Empty means the default profile with the historical locations.
+	Profile      string
+	DownloadsDir string // overrides the profile's default downloads directory
+	// Retry is the retry policy of idempotent GET requests, DefaultRetryPolicy if zero
+	Retry RetryPolicy
+	// BreakerThreshold and BreakerCooldown configure the server's circuit breaker
+	// (DefaultBreakerThreshold and DefaultBreakerCooldown if zero)
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+	// MaxBitrate is the highest bitrate, in bits per second, streamed without transcoding
+	// (0 for no limit)
+	MaxBitrate int64
+	// AudioLanguages and SubtitleLanguages are the languages to play, most preferred
+	// first, as ISO 639-2 codes (e.g. "jpn"). They take precedence over the languages
+	// set in the user's configuration on the server.
+	AudioLanguages    []string
+	SubtitleLanguages []string
+	// SegmentActions tells what to do with each type of media segment, see SegmentAction
+	SegmentActions map[string]string
 }
 
+// Supported authentication methods
+const (
+	AuthMethodQuickConnect = "quickconnect"
+	AuthMethodPassword     = "password"
+)
+
 // NewClient creates a new Jellyfin client with the given configuration
 func NewClient(config *Config) *Client {
 	if config.ClientName == "" {
@@ -45,6 +88,9 @@ func NewClient(config *Config) *Client {
 	if config.Timeout == 0 {
 		config.Timeout = 10 * time.Second
 	}
+	if config.Retry.MaxAttempts == 0 {
+		config.Retry = DefaultRetryPolicy()
+	}
 
 	// Optimized HTTP client with enhanced connection pooling
 	transport := &http.Transport{
@@ -64,8 +110,10 @@ func NewClient(config *Config) *Client {
 	}
 
 	client := &Client{
-		config: config,
-		http:   httpClient,
+		config:  config,
+		http:    httpClient,
+		reauth:  ReloadSession,
+		breaker: breakerFor(config.ServerURL, config.BreakerThreshold, config.BreakerCooldown),
 	}
 
 	// Initialize API modules
@@ -74,6 +122,8 @@ func NewClient(config *Config) *Client {
 	client.Items = &ItemsAPI{client: client}
 	client.Playback = &PlaybackAPI{client: client}
 	client.Search = &SearchAPI{client: client}
+	client.Playlists = &PlaylistsAPI{client: client}
+	client.LiveTv = &LiveTvAPI{client: client}
 	client.Download = &DownloadAPI{
 		client: client,
 		Queue:  NewDownloadQueue(),
@@ -116,6 +166,15 @@ func (c *Client) SetDeviceID(deviceID string) {
 	c.config.DeviceID = deviceID
 }
 
+// CacheDir returns the directory holding the session and other state of the client's
+// profile
+func (c *Client) CacheDir() string {
+	if profile := c.config.Profile; profile != "" {
+		return filepath.Join(xdg.CacheHome, c.config.ClientName, "profiles", profile)
+	}
+	return filepath.Join(xdg.CacheHome, c.config.ClientName)
+}
+
 // IsAuthenticated checks if the client has authentication credentials
 func (c *Client) IsAuthenticated() bool {
 	return c.config.AccessToken != "" && c.config.UserID != ""
@@ -135,43 +194,39 @@ func (c *Client) GetTokenHeader() string {
 // doRequest creates and executes an authenticated HTTP request, returning the response body.
 // It sets the full MediaBrowser authorization header and Content-Type.
 // The caller is responsible for providing the correct method, URL, and optional body.
-func (c *Client) doRequest(method, url string, body io.Reader) ([]byte, error) {
-	req, err := http.NewRequest(method, url, body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf(
-		"MediaBrowser Client=\"%s\", Device=\"%s\", DeviceId=\"%s\", Version=\"%s\", Token=\"%s\"",
-		c.config.ClientName,
-		c.config.ClientName,
-		c.config.DeviceID,
-		c.config.Version,
-		c.config.AccessToken,
-	))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := c.http.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+// The request is aborted when ctx is cancelled.
+func (c *Client) doRequest(ctx context.Context, method, url string, body io.Reader) ([]byte, error) {
+	// Buffer the body so the request can be replayed after re-authentication
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return nil, fmt.Errorf("API returned %d: %s", resp.StatusCode, string(respBody))
-	}
-
-	return respBody, nil
+	return c.send(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Authorization", fmt.Sprintf(
+			"MediaBrowser Client=\"%s\", Device=\"%s\", DeviceId=\"%s\", Version=\"%s\", Token=\"%s\"",
+			c.config.ClientName,
+			c.config.ClientName,
+			c.config.DeviceID,
+			c.config.Version,
+			c.config.AccessToken,
+		))
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 }
 
 // doRequestDecode creates and executes an authenticated HTTP request, decoding the JSON response into dest.
-func (c *Client) doRequestDecode(method, url string, body io.Reader, dest interface{}) error {
-	respBody, err := c.doRequest(method, url, body)
+func (c *Client) doRequestDecode(ctx context.Context, method, url string, body io.Reader, dest interface{}) error {
+	respBody, err := c.doRequest(ctx, method, url, body)
 	if err != nil {
 		return err
 	}
@@ -185,30 +240,48 @@ func (c *Client) doRequestDecode(method, url string, body io.Reader, dest interf
 
 // doTokenRequest creates and executes an HTTP request with the simple Token authorization header.
 // Used by libraries and auth endpoints that use the shorter auth format.
-func (c *Client) doTokenRequest(method, url string) ([]byte, error) {
-	req, err := http.NewRequest(method, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+func (c *Client) doTokenRequest(ctx context.Context, method, url string) ([]byte, error) {
+	return c.send(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", c.config.AccessToken))
+		req.Header.Set("User-Agent", fmt.Sprintf("%s/%s", c.config.ClientName, c.config.Version))
+		return req, nil
+	})
+}
 
-	req.Header.Set("Authorization", fmt.Sprintf("MediaBrowser Token=\"%s\"", c.config.AccessToken))
-	req.Header.Set("User-Agent", fmt.Sprintf("%s/%s", c.config.ClientName, c.config.Version))
+// send executes the request built by newRequest and returns the response body.
+// Error statuses are returned as *APIError. Transient failures are retried (see
+// sendWithRetry). When the server rejects the token, the re-auth hook runs and
+// the request is rebuilt with the new token and retried once.
+func (c *Client) send(ctx context.Context, newRequest func() (*http.Request, error)) ([]byte, error) {
+	token := c.config.AccessToken
+	body, err := c.sendWithRetry(ctx, newRequest)
+	if errors.Is(err, ErrUnauthorized) && c.reauthenticate(ctx, token) {
+		body, err = c.sendWithRetry(ctx, newRequest)
+	}
+	return body, err
+}
 
+func (c *Client) sendOnce(req *http.Request) ([]byte, error) {
 	resp, err := c.http.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned HTTP %d", resp.StatusCode)
-	}
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, newAPIError(req, resp, body)
+	}
+
 	return body, nil
 }
 
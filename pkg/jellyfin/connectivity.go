@@ -0,0 +1,95 @@
+package jellyfin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// OnlineProbe is the outcome of ProbeOnline, applied to the client with ApplyOnline
+type OnlineProbe struct {
+	session *SessionData // saved session to restore, nil to keep the client's own
+}
+
+// GoOnline switches an offline client back to online mode if the server answers.
+// When the client has no session yet the saved one is restored; if it is missing or
+// expired the client is online but unauthenticated afterwards (see NeedsLogin).
+func (c *Client) GoOnline() error {
+	if !c.IsOfflineMode() {
+		return nil
+	}
+
+	probe, err := c.ProbeOnline()
+	if err != nil {
+		return err
+	}
+	c.ApplyOnline(probe)
+	return nil
+}
+
+// ProbeOnline does the network part of GoOnline without changing the client, so that
+// it can run concurrently with code using the client. The client goes online once the
+// result is passed to ApplyOnline.
+func (c *Client) ProbeOnline() (*OnlineProbe, error) {
+	if err := c.Auth.TestConnection(); err != nil {
+		return nil, fmt.Errorf("server is still unreachable: %w", err)
+	}
+
+	probe := &OnlineProbe{}
+	if !c.IsAuthenticated() {
+		probe.session = c.Auth.restoreSession()
+	}
+	return probe, nil
+}
+
+// ApplyOnline switches the client back to online mode with the result of ProbeOnline
+func (c *Client) ApplyOnline(probe *OnlineProbe) {
+	if probe.session != nil && !c.IsAuthenticated() {
+		c.config.AccessToken = probe.session.AccessToken
+		c.config.UserID = probe.session.UserID
+	}
+	c.offline.Store(false)
+}
+
+// GoOffline switches the client to offline mode. The session is kept so that
+// GoOnline can resume without logging in again.
+func (c *Client) GoOffline() {
+	c.offline.Store(true)
+}
+
+// CheckConnectivity probes the server and switches the client between online and
+// offline mode accordingly. It reports whether the mode changed. Only failures to
+// reach the server take the client offline; error responses from a reachable
+// server leave it online. Offline clients used concurrently should be probed with
+// ProbeOnline instead.
+func (c *Client) CheckConnectivity() (bool, error) {
+	if c.IsOfflineMode() {
+		if err := c.GoOnline(); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if err := c.Auth.TestConnection(); err != nil {
+		if !IsConnectionError(err) {
+			return false, err
+		}
+		c.GoOffline()
+		return true, err
+	}
+	return false, nil
+}
+
+// IsConnectionError reports whether err was caused by failing to reach the server,
+// as opposed to an error returned by the server
+func IsConnectionError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
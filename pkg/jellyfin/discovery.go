@@ -0,0 +1,99 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// DiscoveryPort is the UDP port Jellyfin servers listen on for discovery probes
+	DiscoveryPort = 7359
+	// discoveryProbe is the message Jellyfin servers answer to
+	discoveryProbe = "who is JellyfinServer?"
+)
+
+// DiscoveredServer is a Jellyfin server that answered the LAN discovery probe
+type DiscoveredServer struct {
+	Address         string `json:"Address"`
+	ID              string `json:"Id"`
+	Name            string `json:"Name"`
+	EndpointAddress string `json:"EndpointAddress,omitempty"`
+}
+
+// DiscoverServers broadcasts the Jellyfin discovery probe on the local network
+// and collects the servers that answer within the timeout
+func DiscoverServers(timeout time.Duration) ([]DiscoveredServer, error) {
+	return DiscoverServersAt(fmt.Sprintf("255.255.255.255:%d", DiscoveryPort), timeout)
+}
+
+// DiscoverServersAt sends the discovery probe to a specific address (broadcast or unicast)
+// and collects the servers that answer within the timeout. Duplicate answers are dropped.
+func DiscoverServersAt(address string, timeout time.Duration) ([]DiscoveredServer, error) {
+	target, err := net.ResolveUDPAddr("udp4", address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid discovery address: %w", err)
+	}
+
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open discovery socket: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.WriteToUDP([]byte(discoveryProbe), target); err != nil {
+		return nil, fmt.Errorf("failed to send discovery probe: %w", err)
+	}
+
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, fmt.Errorf("failed to set discovery deadline: %w", err)
+	}
+
+	var servers []DiscoveredServer
+	seen := make(map[string]bool)
+	buffer := make([]byte, 4096)
+
+	for {
+		n, _, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			if errors.Is(err, os.ErrDeadlineExceeded) {
+				break
+			}
+			return servers, fmt.Errorf("failed to read discovery response: %w", err)
+		}
+
+		var server DiscoveredServer
+		if err := json.Unmarshal(buffer[:n], &server); err != nil || server.Address == "" {
+			continue // Not a Jellyfin answer
+		}
+		server.Address = strings.TrimRight(server.Address, "/")
+
+		key := server.ID
+		if key == "" {
+			key = server.Address
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		servers = append(servers, server)
+	}
+
+	return servers, nil
+}
+
+// NormalizeServerURL adds a scheme to a bare host and strips trailing slashes
+func NormalizeServerURL(raw string) string {
+	url := strings.TrimSpace(raw)
+	if url == "" {
+		return ""
+	}
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	return strings.TrimRight(url, "/")
+}
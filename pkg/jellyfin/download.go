@@ -22,6 +22,42 @@ var (
 	nonAlphanumRe    = regexp.MustCompile(`[^a-zA-Z0-9]+`)
 )
 
+// audioExtensions are the extensions of downloaded music. Tracks keep the format of
+// the original file, while videos are always saved as .mkv.
+var audioExtensions = map[string]bool{
+	".mp3": true, ".flac": true, ".m4a": true, ".mp4": true, ".aac": true,
+	".ogg": true, ".oga": true, ".opus": true, ".wav": true, ".wma": true, ".ape": true,
+}
+
+// IsMediaFile reports whether a file in the downloads directory is a downloaded video
+// or track, as opposed to metadata sidecars and partial downloads
+func IsMediaFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".mkv" || audioExtensions[ext]
+}
+
+// containerExtensions maps the container names the server reports that are not an
+// extension themselves to one of audioExtensions
+var containerExtensions = map[string]string{
+	"mpeg": ".mp3", "mpa": ".mp3", "vorbis": ".ogg", "asf": ".wma", "wave": ".wav",
+}
+
+// audioExtension returns the extension of a downloaded track from its container. The
+// server lists the formats a container may be ("mov,mp4,m4a"), so the first one that
+// is a known audio extension is used; it always returns one of audioExtensions
+func audioExtension(item *DetailedItem) string {
+	for container := range strings.SplitSeq(strings.ToLower(item.Container), ",") {
+		container = strings.TrimSpace(container)
+		if ext := "." + container; audioExtensions[ext] {
+			return ext
+		}
+		if ext, ok := containerExtensions[container]; ok {
+			return ext
+		}
+	}
+	return ".mp3"
+}
+
 // DownloadAPI handles video download operations
 type DownloadAPI struct {
 	client       *Client
@@ -71,14 +107,15 @@ func (s DownloadStatus) String() string {
 
 // QueueItem represents a single item in the download queue
 type QueueItem struct {
-	ID         string
-	Name       string
-	FilePath   string
-	Status     DownloadStatus
-	Progress   float64 // 0-100
-	Downloaded int64
-	Total      int64
-	Error      error
+	ID            string
+	Name          string
+	FilePath      string
+	MediaSourceID string // version to download, empty for the server's default
+	Status        DownloadStatus
+	Progress      float64 // 0-100
+	Downloaded    int64
+	Total         int64
+	Error         error
 }
 
 // QueueStatus holds a snapshot of the download queue state
@@ -114,6 +151,12 @@ func NewDownloadQueue() *DownloadQueue {
 
 // Enqueue adds an item to the download queue. Returns false if already queued.
 func (q *DownloadQueue) Enqueue(id, name, filePath string) bool {
+	return q.EnqueueSource(id, name, filePath, "")
+}
+
+// EnqueueSource is like Enqueue but downloads the given version of the item,
+// the server's default one if mediaSourceID is empty.
+func (q *DownloadQueue) EnqueueSource(id, name, filePath, mediaSourceID string) bool {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
@@ -124,10 +167,11 @@ func (q *DownloadQueue) Enqueue(id, name, filePath string) bool {
 	}
 
 	q.items = append(q.items, &QueueItem{
-		ID:       id,
-		Name:     name,
-		FilePath: filePath,
-		Status:   DownloadPending,
+		ID:            id,
+		Name:          name,
+		FilePath:      filePath,
+		MediaSourceID: mediaSourceID,
+		Status:        DownloadPending,
 	})
 
 	return true
@@ -301,6 +345,7 @@ func (q *DownloadQueue) worker(api *DownloadAPI) {
 			continue
 		}
 		item.FilePath = filePath
+		detail.DownloadedSourceID = item.MediaSourceID
 
 		err = api.DownloadVideo(detail, func(downloaded, total int64) {
 			q.mu.Lock()
@@ -338,10 +383,23 @@ func (q *DownloadQueue) worker(api *DownloadAPI) {
 	}
 }
 
-// GetDownloadsDir returns the downloads directory path in jtui config
+// DefaultDownloadsDir returns where a profile stores its downloads when no directory
+// is configured. The default profile has an empty name.
+func DefaultDownloadsDir(profile string) string {
+	if profile != "" {
+		return filepath.Join(xdg.ConfigHome, "jtui", "profiles", profile, "downloads")
+	}
+	return filepath.Join(xdg.ConfigHome, "jtui", "downloads")
+}
+
+// GetDownloadsDir returns the downloads directory path in jtui config.
+// Each named profile has its own directory so offline content from
+// different servers is never mixed.
 func (d *DownloadAPI) GetDownloadsDir() (string, error) {
-	configHome := xdg.ConfigHome
-	downloadsDir := filepath.Join(configHome, "jtui", "downloads")
+	downloadsDir := d.client.config.DownloadsDir
+	if downloadsDir == "" {
+		downloadsDir = DefaultDownloadsDir(d.client.config.Profile)
+	}
 
 	if err := os.MkdirAll(downloadsDir, 0o755); err != nil {
 		return "", fmt.Errorf("failed to create downloads directory: %w", err)
@@ -351,7 +409,8 @@ func (d *DownloadAPI) GetDownloadsDir() (string, error) {
 }
 
 // BuildVideoPath creates the proper directory structure for a video file
-// respecting Jellyfin's server directory structure (anime/season/episode.mkv)
+// respecting Jellyfin's server directory structure (anime/season/episode.mkv).
+// Music tracks go to Music/Artist/Album/NN - Title with their original extension.
 func (d *DownloadAPI) BuildVideoPath(item *DetailedItem) (string, error) {
 	downloadsDir, err := d.GetDownloadsDir()
 	if err != nil {
@@ -370,7 +429,25 @@ func (d *DownloadAPI) BuildVideoPath(item *DetailedItem) (string, error) {
 	var pathParts []string
 
 	// Handle different content types
-	if item.Type == "Episode" && item.SeriesName != "" {
+	if item.IsAudio() {
+		// Music: Music/Artist/Album/NN - Title.ext
+		artist := item.GetArtist()
+		if artist == "" {
+			artist = "Unknown Artist"
+		}
+		album := item.Album
+		if album == "" {
+			album = "Unknown Album"
+		}
+		pathParts = append(pathParts, "Music", sanitize(artist), sanitize(album))
+
+		fileName := sanitize(item.GetName())
+		if item.IndexNumber > 0 {
+			fileName = fmt.Sprintf("%02d - %s", item.IndexNumber, fileName)
+		}
+		pathParts = append(pathParts, fileName+audioExtension(item))
+
+	} else if item.Type == "Episode" && item.SeriesName != "" {
 		// TV Show: Series/Season XX/Episode
 		seriesName := sanitize(item.SeriesName)
 		pathParts = append(pathParts, seriesName)
@@ -454,8 +531,13 @@ func (d *DownloadAPI) DownloadVideo(item *DetailedItem, progressCallback func(do
 		return fmt.Errorf("failed to build file path: %w", err)
 	}
 
-	// Get download URL
-	downloadURL := d.client.Playback.GetDownloadURL(item.GetID())
+	// Get download URL. Each version of an item is itself an item, whose ID is the
+	// ID of its media source.
+	sourceID := item.GetID()
+	if item.DownloadedSourceID != "" {
+		sourceID = item.DownloadedSourceID
+	}
+	downloadURL := d.client.Playback.GetDownloadURL(sourceID)
 	if downloadURL == "" {
 		return fmt.Errorf("failed to get download URL for item %s", item.GetID())
 	}
@@ -474,7 +556,7 @@ func (d *DownloadAPI) DownloadVideo(item *DetailedItem, progressCallback func(do
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("server returned HTTP %d", resp.StatusCode)
+		return newAPIError(req, resp, nil)
 	}
 
 	// Create temporary file first, then rename on completion
@@ -527,6 +609,8 @@ func (d *DownloadAPI) DownloadVideo(item *DetailedItem, progressCallback func(do
 		return fmt.Errorf("failed to rename file: %w", err)
 	}
 
+	d.downloadSubtitles(item, filePath)
+
 	// Save metadata sidecar for offline browsing
 	d.saveMetadataSidecar(filePath, item)
 
@@ -567,6 +651,12 @@ func (d *DownloadAPI) loadMetadataSidecar(videoPath string) *DetailedItem {
 
 // EnqueueItem adds a single video to the download queue and starts the worker if needed
 func (d *DownloadAPI) EnqueueItem(item *DetailedItem) error {
+	return d.EnqueueItemSource(item, "")
+}
+
+// EnqueueItemSource is like EnqueueItem but downloads the given version of the item,
+// recorded in its sidecar. An empty mediaSourceID downloads the server's default version.
+func (d *DownloadAPI) EnqueueItemSource(item *DetailedItem, mediaSourceID string) error {
 	filePath, err := d.BuildVideoPath(item)
 	if err != nil {
 		return fmt.Errorf("failed to build file path: %w", err)
@@ -577,7 +667,7 @@ func (d *DownloadAPI) EnqueueItem(item *DetailedItem) error {
 		return nil
 	}
 
-	if !d.Queue.Enqueue(item.GetID(), item.GetName(), filePath) {
+	if !d.Queue.EnqueueSource(item.GetID(), item.GetName(), filePath, mediaSourceID) {
 		return fmt.Errorf("item already in queue")
 	}
 
@@ -668,6 +758,42 @@ func (d *DownloadAPI) EnqueueSeason(seriesID, seasonID, seriesName string) (int,
 	return enqueued, nil
 }
 
+// EnqueueAlbum adds all tracks of an album to the download queue
+func (d *DownloadAPI) EnqueueAlbum(albumID string) (int, error) {
+	tracks, err := d.client.Items.GetAlbumTracks(albumID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get tracks for album: %w", err)
+	}
+
+	enqueued := 0
+	for i := range tracks {
+		track := &tracks[i]
+		filePath, err := d.BuildVideoPath(track)
+		if err != nil {
+			continue
+		}
+
+		if downloaded, _, _ := d.IsDownloaded(track); downloaded {
+			continue
+		}
+
+		displayName := track.GetName()
+		if track.Album != "" {
+			displayName = fmt.Sprintf("%s - %s", track.Album, track.GetName())
+		}
+
+		if d.Queue.Enqueue(track.GetID(), displayName, filePath) {
+			enqueued++
+		}
+	}
+
+	if enqueued > 0 {
+		d.Queue.Start(d)
+	}
+
+	return enqueued, nil
+}
+
 // GetLocalVideoPath returns the local file path if video is downloaded
 func (d *DownloadAPI) GetLocalVideoPath(item *DetailedItem) (string, bool) {
 	if downloaded, filePath, err := d.IsDownloaded(item); err == nil && downloaded {
@@ -691,8 +817,13 @@ func (d *DownloadAPI) RemoveDownload(item *DetailedItem) error {
 		}
 	}
 
-	// Remove metadata sidecar if it exists
+	// Remove metadata sidecar and subtitles if they exist
 	os.Remove(metadataSidecarPath(filePath))
+	for _, subtitle := range savedSubtitles(filePath, func(ext string) bool {
+		return subtitleExtensions[ext] || imageSubtitleCodecs[strings.TrimPrefix(ext, ".")]
+	}) {
+		os.Remove(subtitle)
+	}
 
 	// Try to remove empty parent directories
 	parentDir := filepath.Dir(filePath)
@@ -721,7 +852,7 @@ func (d *DownloadAPI) ListDownloads() (map[string]string, error) {
 			return nil // Skip errors, continue walking
 		}
 
-		if !info.IsDir() && strings.HasSuffix(strings.ToLower(path), ".mkv") {
+		if !info.IsDir() && IsMediaFile(path) {
 			// Store relative path from downloads dir for cleaner display
 			relPath, _ := filepath.Rel(downloadsDir, path)
 			downloads[relPath] = path
@@ -759,14 +890,18 @@ type OfflineContent struct {
 	FilePath      string
 	RelativePath  string
 	Name          string
-	Type          string // "Movie", "Episode", "Other"
+	Type          string // "Movie", "Episode", "Audio", "Other"
 	SeriesName    string // For episodes
 	SeasonNumber  int    // For episodes
 	EpisodeNumber int    // For episodes
 	Year          int    // For movies
+	Artist        string // For music
+	Album         string // For music
+	TrackNumber   int    // For music
 	Size          int64
 	ModTime       time.Time
 	Metadata      *DetailedItem // Loaded from sidecar if available
+	SubtitlePaths []string      // Subtitle files saved next to the video
 }
 
 // DiscoverOfflineContent scans the downloads directory and creates virtual content items
@@ -784,8 +919,8 @@ func (d *DownloadAPI) DiscoverOfflineContent() ([]Item, error) {
 			return nil // Skip errors, continue walking
 		}
 
-		// Only process video files
-		if info.IsDir() || !strings.HasSuffix(strings.ToLower(path), ".mkv") {
+		// Only process video and music files
+		if info.IsDir() || !IsMediaFile(path) {
 			return nil
 		}
 
@@ -799,6 +934,7 @@ func (d *DownloadAPI) DiscoverOfflineContent() ([]Item, error) {
 
 		// Try to load richer metadata from sidecar
 		content.Metadata = d.loadMetadataSidecar(path)
+		content.SubtitlePaths = d.LocalSubtitles(path)
 
 		offlineContent = append(offlineContent, content)
 
@@ -817,7 +953,7 @@ func (d *DownloadAPI) parseOfflineContent(fullPath, relativePath string, info os
 	content := OfflineContent{
 		FilePath:     fullPath,
 		RelativePath: relativePath,
-		Name:         strings.TrimSuffix(info.Name(), ".mkv"),
+		Name:         strings.TrimSuffix(info.Name(), filepath.Ext(info.Name())),
 		Size:         info.Size(),
 		ModTime:      info.ModTime(),
 	}
@@ -825,7 +961,20 @@ func (d *DownloadAPI) parseOfflineContent(fullPath, relativePath string, info os
 	pathParts := strings.Split(filepath.Dir(relativePath), string(filepath.Separator))
 
 	// Detect content type based on directory structure
-	if len(pathParts) >= 2 && strings.HasPrefix(pathParts[1], "Season") {
+	if pathParts[0] == "Music" {
+		// Music: Music/Artist/Album/NN - Title
+		content.Type = ItemTypeAudio
+		if len(pathParts) >= 3 {
+			content.Artist = pathParts[1]
+			content.Album = pathParts[2]
+		}
+		if number, title, ok := strings.Cut(content.Name, " - "); ok {
+			if _, err := fmt.Sscanf(number, "%d", &content.TrackNumber); err == nil {
+				content.Name = title
+			}
+		}
+
+	} else if len(pathParts) >= 2 && strings.HasPrefix(pathParts[1], "Season") {
 		// TV Show: Series/Season XX/Episode
 		content.Type = "Episode"
 		content.SeriesName = pathParts[0]
@@ -879,6 +1028,7 @@ func (d *DownloadAPI) convertToItems(offlineContent []OfflineContent) []Item {
 	// Group content by series for TV shows
 	seriesMap := make(map[string][]OfflineContent)
 	var movies []OfflineContent
+	var tracks []OfflineContent
 	var others []OfflineContent
 
 	for _, content := range offlineContent {
@@ -887,6 +1037,8 @@ func (d *DownloadAPI) convertToItems(offlineContent []OfflineContent) []Item {
 			seriesMap[content.SeriesName] = append(seriesMap[content.SeriesName], content)
 		case "Movie":
 			movies = append(movies, content)
+		case ItemTypeAudio:
+			tracks = append(tracks, content)
 		default:
 			others = append(others, content)
 		}
@@ -935,11 +1087,35 @@ func (d *DownloadAPI) convertToItems(offlineContent []OfflineContent) []Item {
 					Type:     "Movie",
 				},
 				ProductionYear: movie.Year,
+				MediaStreams:   localSubtitleStreams(movie.SubtitlePaths),
 			}
 			items = append(items, movieItem)
 		}
 	}
 
+	// Add tracks directly — prefer sidecar metadata
+	for _, track := range tracks {
+		id := fmt.Sprintf("offline-audio-%s", sanitizeID(track.FilePath))
+		if track.Metadata != nil {
+			meta := track.Metadata
+			meta.SimpleItem.ID = id
+			meta.SimpleItem.IsFolder = false
+			items = append(items, meta)
+		} else {
+			items = append(items, &DetailedItem{
+				SimpleItem: SimpleItem{
+					Name:     track.Name,
+					ID:       id,
+					IsFolder: false,
+					Type:     ItemTypeAudio,
+				},
+				IndexNumber: track.TrackNumber,
+				Album:       track.Album,
+				AlbumArtist: track.Artist,
+			})
+		}
+	}
+
 	// Add other content
 	for _, other := range others {
 		otherItem := &DetailedItem{
@@ -949,6 +1125,7 @@ func (d *DownloadAPI) convertToItems(offlineContent []OfflineContent) []Item {
 				IsFolder: false,
 				Type:     "Video",
 			},
+			MediaStreams: localSubtitleStreams(other.SubtitlePaths),
 		}
 		items = append(items, otherItem)
 	}
@@ -971,7 +1148,7 @@ func (d *DownloadAPI) GetOfflineEpisodes(seriesName string) ([]Item, error) {
 			return nil // Skip errors
 		}
 
-		if info.IsDir() || !strings.HasSuffix(strings.ToLower(path), ".mkv") {
+		if info.IsDir() || !IsMediaFile(path) {
 			return nil
 		}
 
@@ -1000,6 +1177,7 @@ func (d *DownloadAPI) GetOfflineEpisodes(seriesName string) ([]Item, error) {
 					SeriesName:        content.SeriesName,
 					ParentIndexNumber: content.SeasonNumber,
 					IndexNumber:       content.EpisodeNumber,
+					MediaStreams:      localSubtitleStreams(d.LocalSubtitles(path)),
 				}
 			}
 
@@ -1015,6 +1193,52 @@ func (d *DownloadAPI) GetOfflineEpisodes(seriesName string) ([]Item, error) {
 	return episodes, nil
 }
 
+// GetOfflineNextEpisode returns the downloaded episode after the given one in season
+// and episode order, as read from the download sidecars, nil if it is the last one.
+// Numbers parsed from file names only stand in for downloads without a sidecar.
+func (d *DownloadAPI) GetOfflineNextEpisode(itemID string) (*DetailedItem, error) {
+	episode, filePath, err := d.GetOfflineItemByID(itemID)
+	if err != nil {
+		return nil, err
+	}
+	if meta := d.loadMetadataSidecar(filePath); meta != nil {
+		episode = meta
+	}
+	downloadsDir, err := d.GetDownloadsDir()
+	if err != nil {
+		return nil, err
+	}
+	relPath, err := filepath.Rel(downloadsDir, filePath)
+	if err != nil {
+		return nil, err
+	}
+	seriesDir, _, _ := strings.Cut(relPath, string(filepath.Separator))
+	episodes, err := d.GetOfflineEpisodes(seriesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var next *DetailedItem
+	for _, item := range episodes {
+		e, ok := item.(*DetailedItem)
+		if !ok || !episodeBefore(episode, e) {
+			continue
+		}
+		if next == nil || episodeBefore(e, next) {
+			next = e
+		}
+	}
+	return next, nil
+}
+
+// episodeBefore reports whether episode a comes before episode b
+func episodeBefore(a, b *DetailedItem) bool {
+	if a.ParentIndexNumber != b.ParentIndexNumber {
+		return a.ParentIndexNumber < b.ParentIndexNumber
+	}
+	return a.IndexNumber < b.IndexNumber
+}
+
 // sanitizeID creates a safe ID from a string
 func sanitizeID(input string) string {
 	sanitized := nonAlphanumRe.ReplaceAllString(input, "-")
@@ -1036,7 +1260,7 @@ func (d *DownloadAPI) GetOfflineItemByID(itemID string) (*DetailedItem, string,
 			return nil
 		}
 
-		if info.IsDir() || !strings.HasSuffix(strings.ToLower(path), ".mkv") {
+		if info.IsDir() || !IsMediaFile(path) {
 			return nil
 		}
 
@@ -1053,6 +1277,8 @@ func (d *DownloadAPI) GetOfflineItemByID(itemID string) (*DetailedItem, string,
 			expectedID = fmt.Sprintf("offline-episode-%s", sanitizeID(content.FilePath))
 		case "Movie":
 			expectedID = fmt.Sprintf("offline-movie-%s", sanitizeID(content.Name))
+		case ItemTypeAudio:
+			expectedID = fmt.Sprintf("offline-audio-%s", sanitizeID(content.FilePath))
 		default:
 			expectedID = fmt.Sprintf("offline-other-%s", sanitizeID(content.Name))
 		}
@@ -1094,6 +1320,12 @@ func (d *DownloadAPI) GetOfflineItemByID(itemID string) (*DetailedItem, string,
 		SeriesName:        foundContent.SeriesName,
 		ParentIndexNumber: foundContent.SeasonNumber,
 		IndexNumber:       foundContent.EpisodeNumber,
+		MediaStreams:      localSubtitleStreams(d.LocalSubtitles(foundPath)),
+	}
+	if foundContent.Type == ItemTypeAudio {
+		item.IndexNumber = foundContent.TrackNumber
+		item.Album = foundContent.Album
+		item.AlbumArtist = foundContent.Artist
 	}
 
 	return item, foundPath, nil
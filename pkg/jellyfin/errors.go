@@ -0,0 +1,71 @@
+package jellyfin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by APIError through errors.Is
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrServer       = errors.New("server error")
+)
+
+// maxErrorMessageLength caps the server message kept in an APIError
+const maxErrorMessageLength = 200
+
+// APIError is returned when the server answers a request with an error status.
+// Use errors.Is with the sentinel errors to check for common cases, or errors.As
+// to inspect the status code.
+type APIError struct {
+	StatusCode int
+	Method     string
+	Endpoint   string // request path, without the query string (which may hold the token)
+	Message    string // message returned by the server, if any
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s: server returned HTTP %d", e.Method, e.Endpoint, e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// Is reports whether the error matches one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// newAPIError builds an APIError from a failed response. body is the response body
+// if it was already read, otherwise it is read from the response.
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	if body == nil {
+		body, _ = io.ReadAll(io.LimitReader(resp.Body, 4096))
+	}
+	message := strings.TrimSpace(string(body))
+	if len(message) > maxErrorMessageLength {
+		message = message[:maxErrorMessageLength] + "..."
+	}
+	return &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		Endpoint:   req.URL.Path,
+		Message:    message,
+	}
+}
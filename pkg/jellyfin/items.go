@@ -1,7 +1,9 @@
 package jellyfin
 
 import (
+	"context"
 	"fmt"
+	"iter"
 	"os"
 	"strings"
 )
@@ -11,9 +13,25 @@ type ItemsAPI struct {
 	client *Client
 }
 
-// Get returns items within a specified parent, optionally including folders.
+// DefaultPageSize is the number of items requested per page when a folder is listed page by page
+const DefaultPageSize = 100
+
+// ItemsPage is one page of the items within a parent
+type ItemsPage struct {
+	Items      []Item
+	StartIndex int // index of the first item of the page within the parent
+	TotalCount int // number of items within the parent
+}
+
+// HasMore reports whether more items follow this page
+func (p *ItemsPage) HasMore() bool {
+	return len(p.Items) > 0 && p.StartIndex+len(p.Items) < p.TotalCount
+}
+
+// GetContext returns items within a specified parent, optionally including folders.
 // Falls back to offline content if in offline mode or if the ID is an offline item.
-func (i *ItemsAPI) Get(parentID string, includeFolders bool) ([]Item, error) {
+// Large folders are better listed with GetPageContext or AllContext.
+func (i *ItemsAPI) GetContext(ctx context.Context, parentID string, includeFolders bool) ([]Item, error) {
 	if i.client.IsOfflineMode() {
 		return i.getOfflineItems(parentID, includeFolders)
 	}
@@ -23,26 +41,157 @@ func (i *ItemsAPI) Get(parentID string, includeFolders bool) ([]Item, error) {
 		return i.getOfflineItems(parentID, includeFolders)
 	}
 
+	page, err := i.QueryContext(ctx, NewFolderQuery(parentID, includeFolders))
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// Get is like GetContext but uses context.Background().
+func (i *ItemsAPI) Get(parentID string, includeFolders bool) ([]Item, error) {
+	return i.GetContext(context.Background(), parentID, includeFolders)
+}
+
+// GetPageContext returns at most limit items within a specified parent, starting at
+// startIndex. Offline content is returned as a single page.
+func (i *ItemsAPI) GetPageContext(ctx context.Context, parentID string, includeFolders bool, startIndex, limit int) (*ItemsPage, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	return i.QueryContext(ctx, NewFolderQuery(parentID, includeFolders).WithPage(startIndex, limit))
+}
+
+// GetPage is like GetPageContext but uses context.Background().
+func (i *ItemsAPI) GetPage(parentID string, includeFolders bool, startIndex, limit int) (*ItemsPage, error) {
+	return i.GetPageContext(context.Background(), parentID, includeFolders, startIndex, limit)
+}
+
+// AllContext iterates over the items within a specified parent, fetching pageSize items
+// at a time as the iteration advances (DefaultPageSize if pageSize is not positive).
+// A failed request ends the iteration after yielding its error with a nil item.
+func (i *ItemsAPI) AllContext(ctx context.Context, parentID string, includeFolders bool, pageSize int) iter.Seq2[Item, error] {
+	return paginate(func(start int) (*ItemsPage, error) {
+		return i.GetPageContext(ctx, parentID, includeFolders, start, pageSize)
+	})
+}
+
+// All is like AllContext but uses context.Background().
+func (i *ItemsAPI) All(parentID string, includeFolders bool, pageSize int) iter.Seq2[Item, error] {
+	return i.AllContext(context.Background(), parentID, includeFolders, pageSize)
+}
+
+// QueryContext returns the page of items matching a query, for example unwatched
+// movies sorted by date added:
+//
+//	q := NewItemsQuery().WithRecursive(true).WithTypes(ItemTypeMovie).WithPlayed(false).
+//		WithSort(SortDescending, SortByDateCreated)
+//
+// Queries for the children of an offline item, or any query in offline mode, return
+// the downloaded content as a single page, unfiltered and unsorted.
+func (i *ItemsAPI) QueryContext(ctx context.Context, q *ItemsQuery) (*ItemsPage, error) {
+	if i.client.IsOfflineMode() || strings.HasPrefix(q.ParentID, "offline-") {
+		items, err := i.getOfflineItems(q.ParentID, true)
+		if err != nil {
+			return nil, err
+		}
+		return &ItemsPage{Items: items, TotalCount: len(items)}, nil
+	}
+
+	response, err := i.list(ctx, "/Items", q)
+	if err != nil {
+		return nil, err
+	}
+	return &ItemsPage{
+		Items:      toItems(response.Items),
+		StartIndex: q.StartIndex,
+		TotalCount: response.TotalRecordCount,
+	}, nil
+}
+
+// Query is like QueryContext but uses context.Background().
+func (i *ItemsAPI) Query(q *ItemsQuery) (*ItemsPage, error) {
+	return i.QueryContext(context.Background(), q)
+}
+
+// QueryAllContext iterates over every item matching a query, fetching pageSize items
+// at a time (DefaultPageSize if pageSize is not positive). The paging of q is ignored.
+// A failed request ends the iteration after yielding its error with a nil item.
+func (i *ItemsAPI) QueryAllContext(ctx context.Context, q *ItemsQuery, pageSize int) iter.Seq2[Item, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return paginate(func(start int) (*ItemsPage, error) {
+		page := *q
+		page.StartIndex, page.Limit, page.SkipTotalCount = start, pageSize, false
+		return i.QueryContext(ctx, &page)
+	})
+}
+
+// QueryAll is like QueryAllContext but uses context.Background().
+func (i *ItemsAPI) QueryAll(q *ItemsQuery, pageSize int) iter.Seq2[Item, error] {
+	return i.QueryAllContext(context.Background(), q, pageSize)
+}
+
+// GetFilterOptionsContext returns the genres, parental ratings and years found among
+// the items within a parent and its subfolders, or the whole library if parentID is empty
+func (i *ItemsAPI) GetFilterOptionsContext(ctx context.Context, parentID string) (*FilterOptions, error) {
 	if !i.client.IsAuthenticated() {
 		return nil, fmt.Errorf("client is not authenticated")
 	}
 
-	url := fmt.Sprintf("%s/Items?ParentId=%s&Fields=BasicSyncInfo,UserData", i.client.config.ServerURL, parentID)
-	if !includeFolders {
-		url += "&ExcludeItemTypes=Folder"
+	q := NewItemsQuery().WithParent(parentID)
+	var options FilterOptions
+	if err := i.client.doRequestDecode(ctx, "GET", i.client.queryURL("/Items/Filters", q), nil, &options); err != nil {
+		return nil, err
 	}
+	return &options, nil
+}
 
-	var result DetailedItemsResponse
-	if err := i.client.doRequestDecode("GET", url, nil, &result); err != nil {
-		return nil, err
+// GetFilterOptions is like GetFilterOptionsContext but uses context.Background().
+func (i *ItemsAPI) GetFilterOptions(parentID string) (*FilterOptions, error) {
+	return i.GetFilterOptionsContext(context.Background(), parentID)
+}
+
+// paginate iterates over the items of the pages returned by fetch, which is called
+// with the start index of each page until a page is the last one or fails
+func paginate(fetch func(startIndex int) (*ItemsPage, error)) iter.Seq2[Item, error] {
+	return func(yield func(Item, error) bool) {
+		for start := 0; ; {
+			page, err := fetch(start)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if !page.HasMore() {
+				return
+			}
+			start += len(page.Items)
+		}
+	}
+}
+
+// list fetches the items matching a query from an item listing endpoint
+func (i *ItemsAPI) list(ctx context.Context, path string, q *ItemsQuery) (*DetailedItemsResponse, error) {
+	if !i.client.IsAuthenticated() {
+		return nil, fmt.Errorf("client is not authenticated")
 	}
 
-	return toItems(result.Items), nil
+	var response DetailedItemsResponse
+	if err := i.client.doRequestDecode(ctx, "GET", i.client.queryURL(path, q), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
 }
 
-// GetDetails returns detailed information about a specific item.
+// GetDetailsContext returns detailed information about a specific item.
 // Falls back to offline item details if the ID is an offline item.
-func (i *ItemsAPI) GetDetails(itemID string) (*DetailedItem, error) {
+func (i *ItemsAPI) GetDetailsContext(ctx context.Context, itemID string) (*DetailedItem, error) {
 	if i.client.IsOfflineMode() {
 		return i.GetOfflineItemDetails(itemID)
 	}
@@ -61,20 +210,25 @@ func (i *ItemsAPI) GetDetails(itemID string) (*DetailedItem, error) {
 	}
 
 	url := fmt.Sprintf(
-		"%s/Users/%s/Items/%s?Fields=BasicSyncInfo,UserData,SeriesInfo",
+		"%s/Users/%s/Items/%s?Fields=BasicSyncInfo,UserData,SeriesInfo,MediaSources,MediaStreams,Chapters",
 		i.client.config.ServerURL,
 		i.client.config.UserID,
 		itemID,
 	)
 
 	var item DetailedItem
-	if err := i.client.doRequestDecode("GET", url, nil, &item); err != nil {
+	if err := i.client.doRequestDecode(ctx, "GET", url, nil, &item); err != nil {
 		return nil, err
 	}
 
 	return &item, nil
 }
 
+// GetDetails is like GetDetailsContext but uses context.Background().
+func (i *ItemsAPI) GetDetails(itemID string) (*DetailedItem, error) {
+	return i.GetDetailsContext(context.Background(), itemID)
+}
+
 // GetImageURL generates an optimized image URL for a specific item and image type
 func (i *ItemsAPI) GetImageURL(itemID, imageType, tag string) string {
 	if tag == "" {
@@ -84,48 +238,37 @@ func (i *ItemsAPI) GetImageURL(itemID, imageType, tag string) string {
 		i.client.config.ServerURL, itemID, imageType, tag)
 }
 
-// GetResumeItems returns items that can be resumed by the current user
-func (i *ItemsAPI) GetResumeItems() ([]Item, error) {
-	if !i.client.IsAuthenticated() {
-		return nil, fmt.Errorf("client is not authenticated")
-	}
-
-	url := fmt.Sprintf(
-		"%s/Users/%s/Items/Resume?Limit=12&Recursive=true&Fields=BasicSyncInfo,UserData,CanDelete,PrimaryImageAspectRatio&EnableImageTypes=Primary,Backdrop,Thumb&EnableTotalRecordCount=false&ImageTypeLimit=1",
-		i.client.config.ServerURL,
-		i.client.config.UserID,
-	)
-
-	var response DetailedItemsResponse
-	if err := i.client.doRequestDecode("GET", url, nil, &response); err != nil {
+// GetResumeItemsContext returns items that can be resumed by the current user
+func (i *ItemsAPI) GetResumeItemsContext(ctx context.Context) ([]Item, error) {
+	path := fmt.Sprintf("/Users/%s/Items/Resume", i.client.config.UserID)
+	response, err := i.list(ctx, path, sectionQuery(12).WithRecursive(true))
+	if err != nil {
 		return nil, err
 	}
-
 	return toItems(response.Items), nil
 }
 
-// GetNextUp returns next up items for TV shows
-func (i *ItemsAPI) GetNextUp() ([]Item, error) {
-	if !i.client.IsAuthenticated() {
-		return nil, fmt.Errorf("client is not authenticated")
-	}
-
-	url := fmt.Sprintf(
-		"%s/Shows/NextUp?UserId=%s&Limit=12&Fields=BasicSyncInfo,UserData,CanDelete,PrimaryImageAspectRatio&EnableImageTypes=Primary,Backdrop,Thumb&EnableTotalRecordCount=false&ImageTypeLimit=1",
-		i.client.config.ServerURL,
-		i.client.config.UserID,
-	)
+// GetResumeItems is like GetResumeItemsContext but uses context.Background().
+func (i *ItemsAPI) GetResumeItems() ([]Item, error) {
+	return i.GetResumeItemsContext(context.Background())
+}
 
-	var response DetailedItemsResponse
-	if err := i.client.doRequestDecode("GET", url, nil, &response); err != nil {
+// GetNextUpContext returns next up items for TV shows
+func (i *ItemsAPI) GetNextUpContext(ctx context.Context) ([]Item, error) {
+	response, err := i.list(ctx, "/Shows/NextUp", sectionQuery(12))
+	if err != nil {
 		return nil, err
 	}
-
 	return toItems(response.Items), nil
 }
 
-// GetAncestors gets the parent hierarchy for an item
-func (i *ItemsAPI) GetAncestors(itemID string) ([]Item, error) {
+// GetNextUp is like GetNextUpContext but uses context.Background().
+func (i *ItemsAPI) GetNextUp() ([]Item, error) {
+	return i.GetNextUpContext(context.Background())
+}
+
+// GetAncestorsContext gets the parent hierarchy for an item
+func (i *ItemsAPI) GetAncestorsContext(ctx context.Context, itemID string) ([]Item, error) {
 	if !i.client.IsAuthenticated() {
 		return nil, fmt.Errorf("client is not authenticated")
 	}
@@ -133,113 +276,216 @@ func (i *ItemsAPI) GetAncestors(itemID string) ([]Item, error) {
 	url := fmt.Sprintf("%s/Items/%s/Ancestors?UserId=%s", i.client.config.ServerURL, itemID, i.client.config.UserID)
 
 	var ancestors []SimpleItem
-	if err := i.client.doRequestDecode("GET", url, nil, &ancestors); err != nil {
+	if err := i.client.doRequestDecode(ctx, "GET", url, nil, &ancestors); err != nil {
 		return nil, err
 	}
 
 	return toItems(ancestors), nil
 }
 
-// GetRecentlyAdded returns recently added items of the specified type (Movie, Series, Episode)
-func (i *ItemsAPI) GetRecentlyAdded(itemType string) ([]Item, error) {
-	if !i.client.IsAuthenticated() {
-		return nil, fmt.Errorf("client is not authenticated")
-	}
-
-	url := fmt.Sprintf(
-		"%s/Users/%s/Items?Limit=24&Recursive=true&SortBy=DateCreated&SortOrder=Descending&IncludeItemTypes=%s&Fields=BasicSyncInfo,UserData,CanDelete,PrimaryImageAspectRatio&EnableImageTypes=Primary,Backdrop,Thumb&EnableTotalRecordCount=false&ImageTypeLimit=1",
-		i.client.config.ServerURL,
-		i.client.config.UserID,
-		itemType,
-	)
+// GetAncestors is like GetAncestorsContext but uses context.Background().
+func (i *ItemsAPI) GetAncestors(itemID string) ([]Item, error) {
+	return i.GetAncestorsContext(context.Background(), itemID)
+}
 
-	var response DetailedItemsResponse
-	if err := i.client.doRequestDecode("GET", url, nil, &response); err != nil {
+// GetRecentlyAddedContext returns recently added items of the specified type (Movie, Series, Episode)
+func (i *ItemsAPI) GetRecentlyAddedContext(ctx context.Context, itemType string) ([]Item, error) {
+	q := sectionQuery(24).
+		WithRecursive(true).
+		WithTypes(itemType).
+		WithSort(SortDescending, SortByDateCreated)
+	page, err := i.QueryContext(ctx, q)
+	if err != nil {
 		return nil, err
 	}
+	return page.Items, nil
+}
 
-	return toItems(response.Items), nil
+// GetRecentlyAdded is like GetRecentlyAddedContext but uses context.Background().
+func (i *ItemsAPI) GetRecentlyAdded(itemType string) ([]Item, error) {
+	return i.GetRecentlyAddedContext(context.Background(), itemType)
 }
 
-// GetRecentlyAddedMovies returns recently added movies
+// GetRecentlyAddedMoviesContext returns recently added movies
+func (i *ItemsAPI) GetRecentlyAddedMoviesContext(ctx context.Context) ([]Item, error) {
+	return i.GetRecentlyAddedContext(ctx, "Movie")
+}
+
+// GetRecentlyAddedMovies is like GetRecentlyAddedMoviesContext but uses context.Background().
 func (i *ItemsAPI) GetRecentlyAddedMovies() ([]Item, error) {
-	return i.GetRecentlyAdded("Movie")
+	return i.GetRecentlyAddedMoviesContext(context.Background())
+}
+
+// GetRecentlyAddedShowsContext returns recently added TV shows
+func (i *ItemsAPI) GetRecentlyAddedShowsContext(ctx context.Context) ([]Item, error) {
+	return i.GetRecentlyAddedContext(ctx, "Series")
 }
 
-// GetRecentlyAddedShows returns recently added TV shows
+// GetRecentlyAddedShows is like GetRecentlyAddedShowsContext but uses context.Background().
 func (i *ItemsAPI) GetRecentlyAddedShows() ([]Item, error) {
-	return i.GetRecentlyAdded("Series")
+	return i.GetRecentlyAddedShowsContext(context.Background())
+}
+
+// GetRecentlyAddedEpisodesContext returns recently added episodes
+func (i *ItemsAPI) GetRecentlyAddedEpisodesContext(ctx context.Context) ([]Item, error) {
+	return i.GetRecentlyAddedContext(ctx, "Episode")
 }
 
-// GetRecentlyAddedEpisodes returns recently added episodes
+// GetRecentlyAddedEpisodes is like GetRecentlyAddedEpisodesContext but uses context.Background().
 func (i *ItemsAPI) GetRecentlyAddedEpisodes() ([]Item, error) {
-	return i.GetRecentlyAdded("Episode")
+	return i.GetRecentlyAddedEpisodesContext(context.Background())
 }
 
-// GetSeasons returns all seasons for a given series
-func (i *ItemsAPI) GetSeasons(seriesID string) ([]Item, error) {
-	if !i.client.IsAuthenticated() {
-		return nil, fmt.Errorf("client is not authenticated")
+// GetFavoritesContext returns the user's favorite items, sorted by name. When item types
+// are given, only favorites of these types are returned.
+func (i *ItemsAPI) GetFavoritesContext(ctx context.Context, itemTypes ...string) ([]Item, error) {
+	q := NewItemsQuery().
+		WithRecursive(true).
+		WithFavorite(true).
+		WithTypes(itemTypes...).
+		WithSort(SortAscending, SortByName).
+		WithFields(sectionFields...)
+	page, err := i.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
 	}
+	return page.Items, nil
+}
 
-	url := fmt.Sprintf(
-		"%s/Shows/%s/Seasons?UserId=%s&Fields=BasicSyncInfo,UserData",
-		i.client.config.ServerURL,
-		seriesID,
-		i.client.config.UserID,
-	)
+// GetFavorites is like GetFavoritesContext but uses context.Background().
+func (i *ItemsAPI) GetFavorites(itemTypes ...string) ([]Item, error) {
+	return i.GetFavoritesContext(context.Background(), itemTypes...)
+}
 
-	var response DetailedItemsResponse
-	if err := i.client.doRequestDecode("GET", url, nil, &response); err != nil {
+// GetSeasonsContext returns all seasons for a given series
+func (i *ItemsAPI) GetSeasonsContext(ctx context.Context, seriesID string) ([]Item, error) {
+	path := fmt.Sprintf("/Shows/%s/Seasons", seriesID)
+	response, err := i.list(ctx, path, NewItemsQuery().WithFields(folderFields...))
+	if err != nil {
 		return nil, err
 	}
-
 	return toItems(response.Items), nil
 }
 
-// GetEpisodes returns all episodes for a given series and season
-func (i *ItemsAPI) GetEpisodes(seriesID, seasonID string) ([]DetailedItem, error) {
-	if !i.client.IsAuthenticated() {
-		return nil, fmt.Errorf("client is not authenticated")
+// GetSeasons is like GetSeasonsContext but uses context.Background().
+func (i *ItemsAPI) GetSeasons(seriesID string) ([]Item, error) {
+	return i.GetSeasonsContext(context.Background(), seriesID)
+}
+
+// GetEpisodesContext returns all episodes for a given series and season
+func (i *ItemsAPI) GetEpisodesContext(ctx context.Context, seriesID, seasonID string) ([]DetailedItem, error) {
+	q := NewItemsQuery().WithFields(episodeFields...)
+	q.SeasonID = seasonID
+	response, err := i.list(ctx, fmt.Sprintf("/Shows/%s/Episodes", seriesID), q)
+	if err != nil {
+		return nil, err
 	}
+	return response.Items, nil
+}
 
-	url := fmt.Sprintf(
-		"%s/Shows/%s/Episodes?UserId=%s&SeasonId=%s&Fields=BasicSyncInfo,UserData,SeriesInfo",
-		i.client.config.ServerURL,
-		seriesID,
-		i.client.config.UserID,
-		seasonID,
-	)
+// GetEpisodes is like GetEpisodesContext but uses context.Background().
+func (i *ItemsAPI) GetEpisodes(seriesID, seasonID string) ([]DetailedItem, error) {
+	return i.GetEpisodesContext(context.Background(), seriesID, seasonID)
+}
 
-	var response DetailedItemsResponse
-	if err := i.client.doRequestDecode("GET", url, nil, &response); err != nil {
+// GetAllEpisodesContext returns all episodes for a given series across all seasons
+func (i *ItemsAPI) GetAllEpisodesContext(ctx context.Context, seriesID string) ([]DetailedItem, error) {
+	q := NewItemsQuery().WithFields(episodeFields...)
+	response, err := i.list(ctx, fmt.Sprintf("/Shows/%s/Episodes", seriesID), q)
+	if err != nil {
 		return nil, err
 	}
-
 	return response.Items, nil
 }
 
-// GetAllEpisodes returns all episodes for a given series across all seasons
+// GetAllEpisodes is like GetAllEpisodesContext but uses context.Background().
 func (i *ItemsAPI) GetAllEpisodes(seriesID string) ([]DetailedItem, error) {
-	if !i.client.IsAuthenticated() {
-		return nil, fmt.Errorf("client is not authenticated")
+	return i.GetAllEpisodesContext(context.Background(), seriesID)
+}
+
+// GetNextEpisodeContext returns the episode of the series after the given one, nil if
+// it is the last one
+func (i *ItemsAPI) GetNextEpisodeContext(ctx context.Context, episode *DetailedItem) (*DetailedItem, error) {
+	if episode.SeriesID == "" {
+		return nil, nil
+	}
+	episodes, err := i.GetAllEpisodesContext(ctx, episode.SeriesID)
+	if err != nil {
+		return nil, err
 	}
+	for index, e := range episodes {
+		if e.GetID() == episode.GetID() && index+1 < len(episodes) {
+			return &episodes[index+1], nil
+		}
+	}
+	return nil, nil
+}
 
-	url := fmt.Sprintf(
-		"%s/Shows/%s/Episodes?UserId=%s&Fields=BasicSyncInfo,UserData,SeriesInfo",
-		i.client.config.ServerURL,
-		seriesID,
-		i.client.config.UserID,
-	)
+// GetNextEpisode is like GetNextEpisodeContext but uses context.Background().
+func (i *ItemsAPI) GetNextEpisode(episode *DetailedItem) (*DetailedItem, error) {
+	return i.GetNextEpisodeContext(context.Background(), episode)
+}
 
-	var response DetailedItemsResponse
-	if err := i.client.doRequestDecode("GET", url, nil, &response); err != nil {
+// GetAlbumTracksContext returns the tracks of an album in disc and track order
+func (i *ItemsAPI) GetAlbumTracksContext(ctx context.Context, albumID string) ([]DetailedItem, error) {
+	response, err := i.list(ctx, "/Items", NewAlbumTracksQuery(albumID))
+	if err != nil {
+		return nil, err
+	}
+	return response.Items, nil
+}
+
+// GetAlbumTracks is like GetAlbumTracksContext but uses context.Background().
+func (i *ItemsAPI) GetAlbumTracks(albumID string) ([]DetailedItem, error) {
+	return i.GetAlbumTracksContext(context.Background(), albumID)
+}
+
+// GetArtistTracksContext returns every track of an artist, by album and track number
+// or in random order when shuffled
+func (i *ItemsAPI) GetArtistTracksContext(ctx context.Context, artistID string, shuffled bool) ([]DetailedItem, error) {
+	q := NewItemsQuery().
+		WithArtists(artistID).
+		WithRecursive(true).
+		WithTypes(ItemTypeAudio).
+		WithFields(folderFields...)
+	if shuffled {
+		q.WithSort(SortAscending, SortByRandom)
+	} else {
+		q.WithSort(SortAscending, SortByAlbum, SortByDiscNumber, SortByTrackNumber)
+	}
+	response, err := i.list(ctx, "/Items", q)
+	if err != nil {
 		return nil, err
 	}
+	return response.Items, nil
+}
+
+// GetArtistTracks is like GetArtistTracksContext but uses context.Background().
+func (i *ItemsAPI) GetArtistTracks(artistID string, shuffled bool) ([]DetailedItem, error) {
+	return i.GetArtistTracksContext(context.Background(), artistID, shuffled)
+}
 
+// GetVideosContext returns every movie, episode and video under a folder or collection,
+// by release date
+func (i *ItemsAPI) GetVideosContext(ctx context.Context, parentID string) ([]DetailedItem, error) {
+	q := NewItemsQuery().
+		WithParent(parentID).
+		WithRecursive(true).
+		WithTypes(ItemTypeMovie, ItemTypeEpisode, ItemTypeVideo).
+		WithSort(SortAscending, SortByPremiereDate, SortByName).
+		WithFields(folderFields...)
+	response, err := i.list(ctx, "/Items", q)
+	if err != nil {
+		return nil, err
+	}
 	return response.Items, nil
 }
 
+// GetVideos is like GetVideosContext but uses context.Background().
+func (i *ItemsAPI) GetVideos(parentID string) ([]DetailedItem, error) {
+	return i.GetVideosContext(context.Background(), parentID)
+}
+
 // getOfflineItems returns offline content for a specific parent ID
 func (i *ItemsAPI) getOfflineItems(parentID string, includeFolders bool) ([]Item, error) {
 	if parentID == "offline-library" {
@@ -1,6 +1,7 @@
 package jellyfin
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
@@ -10,9 +11,9 @@ type LibrariesAPI struct {
 	client *Client
 }
 
-// GetAll returns all media libraries available to the authenticated user.
+// GetAllContext returns all media libraries available to the authenticated user.
 // Falls back to offline content if in offline mode.
-func (l *LibrariesAPI) GetAll() ([]Item, error) {
+func (l *LibrariesAPI) GetAllContext(ctx context.Context) ([]Item, error) {
 	if l.client.IsOfflineMode() {
 		return l.getOfflineLibraries()
 	}
@@ -23,7 +24,7 @@ func (l *LibrariesAPI) GetAll() ([]Item, error) {
 
 	url := fmt.Sprintf("%s/Library/MediaFolders", l.client.config.ServerURL)
 
-	body, err := l.client.doTokenRequest("GET", url)
+	body, err := l.client.doTokenRequest(ctx, "GET", url)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +37,11 @@ func (l *LibrariesAPI) GetAll() ([]Item, error) {
 	return toItems(result.Items), nil
 }
 
+// GetAll is like GetAllContext but uses context.Background().
+func (l *LibrariesAPI) GetAll() ([]Item, error) {
+	return l.GetAllContext(context.Background())
+}
+
 // getOfflineLibraries creates virtual libraries based on offline content
 func (l *LibrariesAPI) getOfflineLibraries() ([]Item, error) {
 	offlineLibrary := &SimpleItem{
@@ -48,9 +54,9 @@ func (l *LibrariesAPI) getOfflineLibraries() ([]Item, error) {
 	return []Item{offlineLibrary}, nil
 }
 
-// GetByName finds a library by its name and returns its ID
-func (l *LibrariesAPI) GetByName(libraryName string) (string, error) {
-	libraries, err := l.GetAll()
+// GetByNameContext finds a library by its name and returns its ID
+func (l *LibrariesAPI) GetByNameContext(ctx context.Context, libraryName string) (string, error) {
+	libraries, err := l.GetAllContext(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -64,15 +70,19 @@ func (l *LibrariesAPI) GetByName(libraryName string) (string, error) {
 	return "", fmt.Errorf("library not found: %s", libraryName)
 }
 
-// GetFolders returns all folders within a specified parent (typically a library)
-func (l *LibrariesAPI) GetFolders(parentID string) ([]Item, error) {
+// GetByName is like GetByNameContext but uses context.Background().
+func (l *LibrariesAPI) GetByName(libraryName string) (string, error) {
+	return l.GetByNameContext(context.Background(), libraryName)
+}
+
+// GetFoldersContext returns all folders within a specified parent (typically a library)
+func (l *LibrariesAPI) GetFoldersContext(ctx context.Context, parentID string) ([]Item, error) {
 	if !l.client.IsAuthenticated() {
 		return nil, fmt.Errorf("client is not authenticated")
 	}
 
-	url := fmt.Sprintf("%s/Items?ParentId=%s&IncludeItemTypes=Folder", l.client.config.ServerURL, parentID)
-
-	body, err := l.client.doTokenRequest("GET", url)
+	q := NewItemsQuery().WithParent(parentID).WithTypes(ItemTypeFolder)
+	body, err := l.client.doTokenRequest(ctx, "GET", l.client.queryURL("/Items", q))
 	if err != nil {
 		return nil, err
 	}
@@ -84,3 +94,8 @@ func (l *LibrariesAPI) GetFolders(parentID string) ([]Item, error) {
 
 	return toItems(result.Items), nil
 }
+
+// GetFolders is like GetFoldersContext but uses context.Background().
+func (l *LibrariesAPI) GetFolders(parentID string) ([]Item, error) {
+	return l.GetFoldersContext(context.Background(), parentID)
+}
@@ -0,0 +1,312 @@
+package jellyfin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// LiveTvAPI handles live TV channels, the program guide, recordings and timers
+type LiveTvAPI struct {
+	client *Client
+}
+
+// Channel is a live TV or radio channel
+type Channel struct {
+	ID             string   `json:"Id"`
+	Name           string   `json:"Name"`
+	Number         string   `json:"ChannelNumber,omitempty"`
+	ChannelType    string   `json:"ChannelType,omitempty"` // "TV" or "Radio"
+	CurrentProgram *Program `json:"CurrentProgram,omitempty"`
+}
+
+// Program is a program of the guide
+type Program struct {
+	ID            string    `json:"Id"`
+	Name          string    `json:"Name"`
+	EpisodeTitle  string    `json:"EpisodeTitle,omitempty"`
+	Overview      string    `json:"Overview,omitempty"`
+	ChannelID     string    `json:"ChannelId"`
+	StartDate     time.Time `json:"StartDate"`
+	EndDate       time.Time `json:"EndDate"`
+	IsSeries      bool      `json:"IsSeries,omitempty"`
+	TimerID       string    `json:"TimerId,omitempty"`       // set when the program is scheduled to record
+	SeriesTimerID string    `json:"SeriesTimerId,omitempty"` // set when the series is scheduled to record
+}
+
+// IsAiring reports whether the program is on air at the given time
+func (p Program) IsAiring(at time.Time) bool {
+	return !at.Before(p.StartDate) && at.Before(p.EndDate)
+}
+
+// Progress returns the elapsed fraction of the program at the given time, between 0 and 1
+func (p Program) Progress(at time.Time) float64 {
+	length := p.EndDate.Sub(p.StartDate)
+	if length <= 0 {
+		return 0
+	}
+	return min(max(float64(at.Sub(p.StartDate))/float64(length), 0), 1)
+}
+
+// Title returns the name of the program followed by its episode title, if any
+func (p Program) Title() string {
+	if p.EpisodeTitle != "" && p.EpisodeTitle != p.Name {
+		return p.Name + ": " + p.EpisodeTitle
+	}
+	return p.Name
+}
+
+// Timer is a scheduled recording
+type Timer struct {
+	ID          string    `json:"Id"`
+	Name        string    `json:"Name"`
+	ChannelID   string    `json:"ChannelId"`
+	ChannelName string    `json:"ChannelName,omitempty"`
+	ProgramID   string    `json:"ProgramId,omitempty"`
+	StartDate   time.Time `json:"StartDate"`
+	EndDate     time.Time `json:"EndDate"`
+	Status      string    `json:"Status,omitempty"` // e.g. "New", "InProgress", "Completed"
+}
+
+// GuideEntry is a row of the program guide: a channel and what it airs now and next
+type GuideEntry struct {
+	Channel Channel
+	Now     *Program // nil when nothing is on air
+	Next    *Program
+}
+
+// guideWindow is how far ahead the guide looks for the next program of each channel
+const guideWindow = 12 * time.Hour
+
+// GetChannelsContext returns the live TV channels available to the user, with the
+// program they are airing
+func (l *LiveTvAPI) GetChannelsContext(ctx context.Context) ([]Channel, error) {
+	if !l.client.IsAuthenticated() {
+		return nil, fmt.Errorf("client is not authenticated")
+	}
+
+	query := url.Values{}
+	query.Set("UserId", l.client.config.UserID)
+	query.Set("AddCurrentProgram", "true")
+	query.Set("EnableUserData", "false")
+	query.Set("EnableImages", "false")
+
+	var response struct {
+		Items []Channel `json:"Items"`
+	}
+	u := l.client.config.ServerURL + "/LiveTv/Channels?" + query.Encode()
+	if err := l.client.doRequestDecode(ctx, "GET", u, nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Items, nil
+}
+
+// GetChannels is like GetChannelsContext but uses context.Background().
+func (l *LiveTvAPI) GetChannels() ([]Channel, error) {
+	return l.GetChannelsContext(context.Background())
+}
+
+// GetProgramsContext returns the programs of the given channels (all channels if none)
+// that air between from and to, sorted by start date
+func (l *LiveTvAPI) GetProgramsContext(ctx context.Context, from, to time.Time, channelIDs ...string) ([]Program, error) {
+	if !l.client.IsAuthenticated() {
+		return nil, fmt.Errorf("client is not authenticated")
+	}
+
+	query := url.Values{}
+	query.Set("UserId", l.client.config.UserID)
+	query.Set("MinEndDate", from.UTC().Format(time.RFC3339))
+	query.Set("MaxStartDate", to.UTC().Format(time.RFC3339))
+	query.Set("SortBy", "StartDate")
+	query.Set("EnableTotalRecordCount", "false")
+	query.Set("EnableImages", "false")
+	if len(channelIDs) > 0 {
+		query.Set("ChannelIds", strings.Join(channelIDs, ","))
+	}
+
+	var response struct {
+		Items []Program `json:"Items"`
+	}
+	u := l.client.config.ServerURL + "/LiveTv/Programs?" + query.Encode()
+	if err := l.client.doRequestDecode(ctx, "GET", u, nil, &response); err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(response.Items, func(a, b Program) int {
+		return a.StartDate.Compare(b.StartDate)
+	})
+	return response.Items, nil
+}
+
+// GetPrograms is like GetProgramsContext but uses context.Background().
+func (l *LiveTvAPI) GetPrograms(from, to time.Time, channelIDs ...string) ([]Program, error) {
+	return l.GetProgramsContext(context.Background(), from, to, channelIDs...)
+}
+
+// GetGuideContext returns the program guide at the given time: every channel with the
+// program it airs and the one after
+func (l *LiveTvAPI) GetGuideContext(ctx context.Context, at time.Time) ([]GuideEntry, error) {
+	channels, err := l.GetChannelsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(channels) == 0 {
+		return nil, nil
+	}
+	programs, err := l.GetProgramsContext(ctx, at, at.Add(guideWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	byChannel := make(map[string][]Program)
+	for _, program := range programs {
+		byChannel[program.ChannelID] = append(byChannel[program.ChannelID], program)
+	}
+
+	guide := make([]GuideEntry, 0, len(channels))
+	for _, channel := range channels {
+		entry := GuideEntry{Channel: channel}
+		upcoming := byChannel[channel.ID]
+		if len(upcoming) > 0 && upcoming[0].IsAiring(at) {
+			entry.Now = &upcoming[0]
+			upcoming = upcoming[1:]
+		} else if channel.CurrentProgram != nil && channel.CurrentProgram.IsAiring(at) {
+			entry.Now = channel.CurrentProgram
+		}
+		if len(upcoming) > 0 {
+			entry.Next = &upcoming[0]
+		}
+		guide = append(guide, entry)
+	}
+	return guide, nil
+}
+
+// GetGuide is like GetGuideContext but uses context.Background().
+func (l *LiveTvAPI) GetGuide(at time.Time) ([]GuideEntry, error) {
+	return l.GetGuideContext(context.Background(), at)
+}
+
+// GetRecordingsContext returns the recordings of the user, newest first
+func (l *LiveTvAPI) GetRecordingsContext(ctx context.Context) ([]Item, error) {
+	response, err := l.client.Items.list(ctx, "/LiveTv/Recordings", NewItemsQuery().WithFields(sectionFields...))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(response.Items, func(a, b DetailedItem) int {
+		return b.DateCreated.Compare(a.DateCreated)
+	})
+	return toItems(response.Items), nil
+}
+
+// GetRecordings is like GetRecordingsContext but uses context.Background().
+func (l *LiveTvAPI) GetRecordings() ([]Item, error) {
+	return l.GetRecordingsContext(context.Background())
+}
+
+// GetTimersContext returns the scheduled recordings, soonest first
+func (l *LiveTvAPI) GetTimersContext(ctx context.Context) ([]Timer, error) {
+	if !l.client.IsAuthenticated() {
+		return nil, fmt.Errorf("client is not authenticated")
+	}
+
+	var response struct {
+		Items []Timer `json:"Items"`
+	}
+	if err := l.client.doRequestDecode(ctx, "GET", l.client.config.ServerURL+"/LiveTv/Timers", nil, &response); err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(response.Items, func(a, b Timer) int {
+		return a.StartDate.Compare(b.StartDate)
+	})
+	return response.Items, nil
+}
+
+// GetTimers is like GetTimersContext but uses context.Background().
+func (l *LiveTvAPI) GetTimers() ([]Timer, error) {
+	return l.GetTimersContext(context.Background())
+}
+
+// RecordProgramContext schedules the recording of a program with the server's default
+// timer settings (padding, keep-up-to, ...)
+func (l *LiveTvAPI) RecordProgramContext(ctx context.Context, programID string) error {
+	if !l.client.IsAuthenticated() {
+		return fmt.Errorf("client is not authenticated")
+	}
+
+	// The defaults are sent back unchanged as the new timer
+	u := fmt.Sprintf("%s/LiveTv/Timers/Defaults?programId=%s", l.client.config.ServerURL, url.QueryEscape(programID))
+	defaults, err := l.client.doRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get timer defaults: %w", err)
+	}
+	_, err = l.client.doRequest(ctx, "POST", l.client.config.ServerURL+"/LiveTv/Timers", bytes.NewReader(defaults))
+	return err
+}
+
+// RecordProgram is like RecordProgramContext but uses context.Background().
+func (l *LiveTvAPI) RecordProgram(programID string) error {
+	return l.RecordProgramContext(context.Background(), programID)
+}
+
+// CancelTimerContext cancels a scheduled recording
+func (l *LiveTvAPI) CancelTimerContext(ctx context.Context, timerID string) error {
+	if !l.client.IsAuthenticated() {
+		return fmt.Errorf("client is not authenticated")
+	}
+
+	_, err := l.client.doRequest(ctx, "DELETE", l.client.config.ServerURL+"/LiveTv/Timers/"+timerID, nil)
+	return err
+}
+
+// CancelTimer is like CancelTimerContext but uses context.Background().
+func (l *LiveTvAPI) CancelTimer(timerID string) error {
+	return l.CancelTimerContext(context.Background(), timerID)
+}
+
+// CancelSeriesTimerContext cancels the recording of every episode of a series
+func (l *LiveTvAPI) CancelSeriesTimerContext(ctx context.Context, seriesTimerID string) error {
+	if !l.client.IsAuthenticated() {
+		return fmt.Errorf("client is not authenticated")
+	}
+
+	_, err := l.client.doRequest(ctx, "DELETE", l.client.config.ServerURL+"/LiveTv/SeriesTimers/"+seriesTimerID, nil)
+	return err
+}
+
+// CancelSeriesTimer is like CancelSeriesTimerContext but uses context.Background().
+func (l *LiveTvAPI) CancelSeriesTimer(seriesTimerID string) error {
+	return l.CancelSeriesTimerContext(context.Background(), seriesTimerID)
+}
+
+// OpenStreamContext opens a live stream of a channel through PlaybackInfo. The server
+// keeps a tuner busy until the stream is closed with CloseStream.
+func (l *LiveTvAPI) OpenStreamContext(ctx context.Context, channelID string) (*StreamInfo, error) {
+	stream, err := l.client.Playback.negotiate(ctx, channelID, false, playbackInfoRequest{AutoOpenLiveStream: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open live stream: %w", err)
+	}
+	return stream, nil
+}
+
+// OpenStream is like OpenStreamContext but uses context.Background().
+func (l *LiveTvAPI) OpenStream(channelID string) (*StreamInfo, error) {
+	return l.OpenStreamContext(context.Background(), channelID)
+}
+
+// CloseStreamContext closes a live stream opened by OpenStream, freeing its tuner
+func (l *LiveTvAPI) CloseStreamContext(ctx context.Context, stream *StreamInfo) error {
+	if stream.LiveStreamID == "" {
+		return nil
+	}
+	u := fmt.Sprintf("%s/LiveStreams/Close?liveStreamId=%s", l.client.config.ServerURL, url.QueryEscape(stream.LiveStreamID))
+	_, err := l.client.doRequest(ctx, "POST", u, nil)
+	return err
+}
+
+// CloseStream is like CloseStreamContext but uses context.Background().
+func (l *LiveTvAPI) CloseStream(stream *StreamInfo) error {
+	return l.CloseStreamContext(context.Background(), stream)
+}
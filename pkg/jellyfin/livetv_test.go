@@ -0,0 +1,178 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// liveTvFixture is a stub server answering the live TV endpoints with canned responses
+// and recording the requests it got
+type liveTvFixture struct {
+	mu       sync.Mutex
+	requests []string // "METHOD path"
+	posted   string   // body of the last POST
+}
+
+func (f *liveTvFixture) handler(t *testing.T, responses map[string]any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			f.posted = string(body)
+		}
+		f.mu.Unlock()
+
+		response, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if response == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			t.Errorf("encode %s: %v", r.URL.Path, err)
+		}
+	}
+}
+
+func (f *liveTvFixture) got(t *testing.T, want ...string) {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.requests) != len(want) {
+		t.Fatalf("requests = %v, want %v", f.requests, want)
+	}
+	for i := range want {
+		if f.requests[i] != want[i] {
+			t.Errorf("request %d = %s, want %s", i, f.requests[i], want[i])
+		}
+	}
+}
+
+func TestGetGuide(t *testing.T) {
+	at := time.Date(2026, 3, 1, 20, 30, 0, 0, time.UTC)
+	hour := func(h int) time.Time { return time.Date(2026, 3, 1, h, 0, 0, 0, time.UTC) }
+	fixture := &liveTvFixture{}
+	client, _ := newTestClient(t, fixture.handler(t, map[string]any{
+		"GET /LiveTv/Channels": map[string]any{"Items": []Channel{
+			{ID: "c1", Name: "One", Number: "1"},
+			{ID: "c2", Name: "Two", Number: "2", CurrentProgram: &Program{ID: "p0", ChannelID: "c2", StartDate: hour(19), EndDate: hour(22)}},
+			{ID: "c3", Name: "Three", Number: "3"},
+		}},
+		// Out of order, as the guide must sort them by start date
+		"GET /LiveTv/Programs": map[string]any{"Items": []Program{
+			{ID: "p2", Name: "Late show", ChannelID: "c1", StartDate: hour(21), EndDate: hour(22)},
+			{ID: "p1", Name: "News", ChannelID: "c1", StartDate: hour(20), EndDate: hour(21), SeriesTimerID: "s1"},
+			{ID: "p3", Name: "Movie", ChannelID: "c2", StartDate: hour(22), EndDate: hour(23)},
+		}},
+	}), 10, time.Minute)
+
+	guide, err := client.LiveTv.GetGuide(at)
+	if err != nil {
+		t.Fatalf("GetGuide: %v", err)
+	}
+	fixture.got(t, "GET /LiveTv/Channels", "GET /LiveTv/Programs")
+	if len(guide) != 3 {
+		t.Fatalf("guide has %d entries, want 3", len(guide))
+	}
+
+	programID := func(p *Program) string {
+		if p == nil {
+			return ""
+		}
+		return p.ID
+	}
+	for i, want := range []struct{ now, next string }{
+		{"p1", "p2"}, // airing program and the next one from the programs
+		{"p0", "p3"}, // airing program of the channel, not in the programs
+		{"", ""},     // nothing on air
+	} {
+		entry := guide[i]
+		if programID(entry.Now) != want.now || programID(entry.Next) != want.next {
+			t.Errorf("%s: now/next = %q/%q, want %q/%q",
+				entry.Channel.Name, programID(entry.Now), programID(entry.Next), want.now, want.next)
+		}
+	}
+	if guide[0].Now.SeriesTimerID != "s1" {
+		t.Errorf("series timer of %s = %q, want s1", guide[0].Now.Name, guide[0].Now.SeriesTimerID)
+	}
+}
+
+func TestGetGuideWithoutChannels(t *testing.T) {
+	fixture := &liveTvFixture{}
+	client, _ := newTestClient(t, fixture.handler(t, map[string]any{
+		"GET /LiveTv/Channels": map[string]any{"Items": []Channel{}},
+	}), 10, time.Minute)
+
+	guide, err := client.LiveTv.GetGuide(time.Now())
+	if err != nil || len(guide) != 0 {
+		t.Fatalf("GetGuide = %v, %v, want an empty guide", guide, err)
+	}
+	fixture.got(t, "GET /LiveTv/Channels")
+}
+
+func TestGetRecordingsAndTimers(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 20, 0, 0, 0, time.UTC) }
+	fixture := &liveTvFixture{}
+	client, _ := newTestClient(t, fixture.handler(t, map[string]any{
+		"GET /LiveTv/Recordings": map[string]any{"Items": []DetailedItem{
+			{SimpleItem: SimpleItem{ID: "r1", Name: "Older"}, DateCreated: day(1)},
+			{SimpleItem: SimpleItem{ID: "r2", Name: "Newer"}, DateCreated: day(3)},
+		}},
+		"GET /LiveTv/Timers": map[string]any{"Items": []Timer{
+			{ID: "t1", Name: "Later", StartDate: day(9)},
+			{ID: "t2", Name: "Sooner", StartDate: day(5)},
+		}},
+	}), 10, time.Minute)
+
+	recordings, err := client.LiveTv.GetRecordings()
+	if err != nil {
+		t.Fatalf("GetRecordings: %v", err)
+	}
+	if len(recordings) != 2 || recordings[0].GetID() != "r2" {
+		t.Errorf("recordings = %v, want the newest first", recordings)
+	}
+
+	timers, err := client.LiveTv.GetTimers()
+	if err != nil {
+		t.Fatalf("GetTimers: %v", err)
+	}
+	if len(timers) != 2 || timers[0].ID != "t2" {
+		t.Errorf("timers = %v, want the soonest first", timers)
+	}
+}
+
+func TestRecordAndCancel(t *testing.T) {
+	defaults := map[string]any{"ProgramId": "p1", "PrePaddingSeconds": 60}
+	fixture := &liveTvFixture{}
+	client, _ := newTestClient(t, fixture.handler(t, map[string]any{
+		"GET /LiveTv/Timers/Defaults":    defaults,
+		"POST /LiveTv/Timers":            nil,
+		"DELETE /LiveTv/Timers/t1":       nil,
+		"DELETE /LiveTv/SeriesTimers/s1": nil,
+	}), 10, time.Minute)
+
+	if err := client.LiveTv.RecordProgram("p1"); err != nil {
+		t.Fatalf("RecordProgram: %v", err)
+	}
+	var posted map[string]any
+	if err := json.Unmarshal([]byte(fixture.posted), &posted); err != nil || posted["ProgramId"] != "p1" {
+		t.Errorf("posted timer = %s, want the defaults sent back", fixture.posted)
+	}
+	if err := client.LiveTv.CancelTimer("t1"); err != nil {
+		t.Fatalf("CancelTimer: %v", err)
+	}
+	if err := client.LiveTv.CancelSeriesTimer("s1"); err != nil {
+		t.Fatalf("CancelSeriesTimer: %v", err)
+	}
+	fixture.got(t,
+		"GET /LiveTv/Timers/Defaults", "POST /LiveTv/Timers",
+		"DELETE /LiveTv/Timers/t1", "DELETE /LiveTv/SeriesTimers/s1")
+}
@@ -2,17 +2,23 @@ package jellyfin
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 )
 
 // PlaybackAPI handles playback-related operations
 type PlaybackAPI struct {
 	client *Client
+
+	mu           sync.Mutex
+	userConfig   *UserConfiguration // cached playback settings of the user
+	userConfigID string             // user the cached settings belong to
 }
 
 // reportPlayback is a shared helper for ReportStart, ReportStop, and ReportProgress
-func (p *PlaybackAPI) reportPlayback(endpoint string, data PlaybackInfo) error {
+func (p *PlaybackAPI) reportPlayback(ctx context.Context, endpoint string, data PlaybackInfo) error {
 	if !p.client.IsAuthenticated() {
 		return fmt.Errorf("client is not authenticated")
 	}
@@ -24,41 +30,53 @@ func (p *PlaybackAPI) reportPlayback(endpoint string, data PlaybackInfo) error {
 		return fmt.Errorf("failed to marshal playback data: %w", err)
 	}
 
-	_, err = p.client.doRequest("POST", url, bytes.NewBuffer(jsonData))
+	_, err = p.client.doRequest(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	return err
 }
 
-// ReportStart reports that playback has started for progress tracking
-func (p *PlaybackAPI) ReportStart(itemID string) error {
-	return p.reportPlayback("", PlaybackInfo{
-		ItemID:        itemID,
+// playbackReport describes the playback of a stream to the server
+func (p *PlaybackAPI) playbackReport(stream *StreamInfo, positionTicks int64) PlaybackInfo {
+	return PlaybackInfo{
+		ItemID:        stream.ItemID,
 		SessionID:     p.client.config.DeviceID,
-		MediaSourceID: itemID,
+		MediaSourceID: stream.MediaSourceID,
+		PlaySessionID: stream.PlaySessionID,
+		LiveStreamID:  stream.LiveStreamID,
+		PositionTicks: positionTicks,
 		CanSeek:       true,
-		PlayMethod:    "DirectPlay",
-	})
+		PlayMethod:    stream.PlayMethod,
+	}
 }
 
-// ReportStop reports that playback has stopped and marks the item as watched
-func (p *PlaybackAPI) ReportStop(itemID string, positionTicks int64) error {
-	return p.reportPlayback("/Stopped", PlaybackInfo{
-		ItemID:        itemID,
-		SessionID:     p.client.config.DeviceID,
-		MediaSourceID: itemID,
-		PositionTicks: positionTicks,
-	})
+// ReportStartContext reports that playback of a stream has started for progress tracking
+func (p *PlaybackAPI) ReportStartContext(ctx context.Context, stream *StreamInfo) error {
+	return p.reportPlayback(ctx, "", p.playbackReport(stream, 0))
 }
 
-// ReportProgress reports the current playback progress
-func (p *PlaybackAPI) ReportProgress(itemID string, positionTicks int64) error {
-	return p.reportPlayback("/Progress", PlaybackInfo{
-		ItemID:        itemID,
-		SessionID:     p.client.config.DeviceID,
-		MediaSourceID: itemID,
-		PositionTicks: positionTicks,
-		CanSeek:       true,
-		PlayMethod:    "DirectPlay",
-	})
+// ReportStart is like ReportStartContext but uses context.Background().
+func (p *PlaybackAPI) ReportStart(stream *StreamInfo) error {
+	return p.ReportStartContext(context.Background(), stream)
+}
+
+// ReportStopContext reports that playback of a stream has stopped. The server saves the
+// position, marks the item as watched near its end and ends any transcode of the stream.
+func (p *PlaybackAPI) ReportStopContext(ctx context.Context, stream *StreamInfo, positionTicks int64) error {
+	return p.reportPlayback(ctx, "/Stopped", p.playbackReport(stream, positionTicks))
+}
+
+// ReportStop is like ReportStopContext but uses context.Background().
+func (p *PlaybackAPI) ReportStop(stream *StreamInfo, positionTicks int64) error {
+	return p.ReportStopContext(context.Background(), stream, positionTicks)
+}
+
+// ReportProgressContext reports the current playback progress of a stream
+func (p *PlaybackAPI) ReportProgressContext(ctx context.Context, stream *StreamInfo, positionTicks int64) error {
+	return p.reportPlayback(ctx, "/Progress", p.playbackReport(stream, positionTicks))
+}
+
+// ReportProgress is like ReportProgressContext but uses context.Background().
+func (p *PlaybackAPI) ReportProgress(stream *StreamInfo, positionTicks int64) error {
+	return p.ReportProgressContext(context.Background(), stream, positionTicks)
 }
 
 // GetStreamURL generates a stream URL for an item
@@ -73,36 +91,66 @@ func (p *PlaybackAPI) GetDownloadURL(itemID string) string {
 		p.client.config.ServerURL, itemID, p.client.config.AccessToken)
 }
 
-// GetPlaybackURL returns the appropriate URL for playback (local file or remote stream).
-// Returns the URL and a boolean indicating if it's a local file.
-func (p *PlaybackAPI) GetPlaybackURL(itemID string, item *DetailedItem) (string, bool) {
-	if item != nil {
-		if localPath, isLocal := p.client.Download.GetLocalVideoPath(item); isLocal {
-			return localPath, true
-		}
+// MarkWatchedContext marks an item as watched
+func (p *PlaybackAPI) MarkWatchedContext(ctx context.Context, itemID string) error {
+	if !p.client.IsAuthenticated() {
+		return fmt.Errorf("client is not authenticated")
 	}
 
-	return p.GetDownloadURL(itemID), false
+	url := fmt.Sprintf("%s/Users/%s/PlayedItems/%s", p.client.config.ServerURL, p.client.config.UserID, itemID)
+	_, err := p.client.doRequest(ctx, "POST", url, nil)
+	return err
 }
 
-// MarkWatched marks an item as watched
+// MarkWatched is like MarkWatchedContext but uses context.Background().
 func (p *PlaybackAPI) MarkWatched(itemID string) error {
+	return p.MarkWatchedContext(context.Background(), itemID)
+}
+
+// MarkUnwatchedContext marks an item as unwatched
+func (p *PlaybackAPI) MarkUnwatchedContext(ctx context.Context, itemID string) error {
 	if !p.client.IsAuthenticated() {
 		return fmt.Errorf("client is not authenticated")
 	}
 
 	url := fmt.Sprintf("%s/Users/%s/PlayedItems/%s", p.client.config.ServerURL, p.client.config.UserID, itemID)
-	_, err := p.client.doRequest("POST", url, nil)
+	_, err := p.client.doRequest(ctx, "DELETE", url, nil)
 	return err
 }
 
-// MarkUnwatched marks an item as unwatched
+// MarkUnwatched is like MarkUnwatchedContext but uses context.Background().
 func (p *PlaybackAPI) MarkUnwatched(itemID string) error {
+	return p.MarkUnwatchedContext(context.Background(), itemID)
+}
+
+// MarkFavoriteContext adds an item to the user's favorites
+func (p *PlaybackAPI) MarkFavoriteContext(ctx context.Context, itemID string) error {
 	if !p.client.IsAuthenticated() {
 		return fmt.Errorf("client is not authenticated")
 	}
 
-	url := fmt.Sprintf("%s/Users/%s/PlayedItems/%s", p.client.config.ServerURL, p.client.config.UserID, itemID)
-	_, err := p.client.doRequest("DELETE", url, nil)
+	url := fmt.Sprintf("%s/Users/%s/FavoriteItems/%s", p.client.config.ServerURL, p.client.config.UserID, itemID)
+	_, err := p.client.doRequest(ctx, "POST", url, nil)
+	return err
+}
+
+// MarkFavorite is like MarkFavoriteContext but uses context.Background().
+func (p *PlaybackAPI) MarkFavorite(itemID string) error {
+	return p.MarkFavoriteContext(context.Background(), itemID)
+}
+
+// UnmarkFavoriteContext removes an item from the user's favorites
+func (p *PlaybackAPI) UnmarkFavoriteContext(ctx context.Context, itemID string) error {
+	if !p.client.IsAuthenticated() {
+		return fmt.Errorf("client is not authenticated")
+	}
+
+	url := fmt.Sprintf("%s/Users/%s/FavoriteItems/%s", p.client.config.ServerURL, p.client.config.UserID, itemID)
+	_, err := p.client.doRequest(ctx, "DELETE", url, nil)
 	return err
 }
+
+// UnmarkFavorite is like UnmarkFavoriteContext but uses context.Background().
+func (p *PlaybackAPI) UnmarkFavorite(itemID string) error {
+	return p.UnmarkFavoriteContext(context.Background(), itemID)
+}
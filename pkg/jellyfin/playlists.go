@@ -0,0 +1,170 @@
+package jellyfin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// PlaylistsAPI handles playlist operations
+type PlaylistsAPI struct {
+	client *Client
+}
+
+// playlistRequest is the body of playlist creation and update requests
+type playlistRequest struct {
+	Name   string   `json:"Name"`
+	IDs    []string `json:"Ids,omitempty"`
+	UserID string   `json:"UserId,omitempty"`
+}
+
+// playlistCreationResult is the response to a playlist creation
+type playlistCreationResult struct {
+	ID string `json:"Id"`
+}
+
+// send makes an authenticated request to a playlist endpoint, with body encoded as JSON if not nil
+func (p *PlaylistsAPI) send(ctx context.Context, method, path string, query url.Values, body any) ([]byte, error) {
+	if !p.client.IsAuthenticated() {
+		return nil, fmt.Errorf("client is not authenticated")
+	}
+
+	u := p.client.config.ServerURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var payload io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal playlist data: %w", err)
+		}
+		payload = bytes.NewBuffer(jsonData)
+	}
+	return p.client.doRequest(ctx, method, u, payload)
+}
+
+// GetAllContext returns the playlists of the user, sorted by name
+func (p *PlaylistsAPI) GetAllContext(ctx context.Context) ([]Item, error) {
+	q := NewItemsQuery().
+		WithRecursive(true).
+		WithTypes(ItemTypePlaylist).
+		WithSort(SortAscending, SortByName).
+		WithFields(folderFields...)
+	page, err := p.client.Items.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// GetAll is like GetAllContext but uses context.Background().
+func (p *PlaylistsAPI) GetAll() ([]Item, error) {
+	return p.GetAllContext(context.Background())
+}
+
+// CreateContext creates a playlist holding the given items and returns its ID
+func (p *PlaylistsAPI) CreateContext(ctx context.Context, name string, itemIDs ...string) (string, error) {
+	body, err := p.send(ctx, "POST", "/Playlists", nil, playlistRequest{
+		Name:   name,
+		IDs:    itemIDs,
+		UserID: p.client.config.UserID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var result playlistCreationResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.ID, nil
+}
+
+// Create is like CreateContext but uses context.Background().
+func (p *PlaylistsAPI) Create(name string, itemIDs ...string) (string, error) {
+	return p.CreateContext(context.Background(), name, itemIDs...)
+}
+
+// RenameContext changes the name of a playlist
+func (p *PlaylistsAPI) RenameContext(ctx context.Context, playlistID, name string) error {
+	_, err := p.send(ctx, "POST", "/Playlists/"+playlistID, nil, playlistRequest{Name: name})
+	return err
+}
+
+// Rename is like RenameContext but uses context.Background().
+func (p *PlaylistsAPI) Rename(playlistID, name string) error {
+	return p.RenameContext(context.Background(), playlistID, name)
+}
+
+// DeleteContext deletes a playlist. The items it holds are not affected.
+func (p *PlaylistsAPI) DeleteContext(ctx context.Context, playlistID string) error {
+	_, err := p.send(ctx, "DELETE", "/Items/"+playlistID, nil, nil)
+	return err
+}
+
+// Delete is like DeleteContext but uses context.Background().
+func (p *PlaylistsAPI) Delete(playlistID string) error {
+	return p.DeleteContext(context.Background(), playlistID)
+}
+
+// GetEntriesContext returns the items of a playlist in playlist order. Each item has
+// its PlaylistItemID set, which identifies the entry when removing or moving it.
+func (p *PlaylistsAPI) GetEntriesContext(ctx context.Context, playlistID string) ([]DetailedItem, error) {
+	q := NewItemsQuery().WithFields(sectionFields...)
+	response, err := p.client.Items.list(ctx, "/Playlists/"+playlistID+"/Items", q)
+	if err != nil {
+		return nil, err
+	}
+	return response.Items, nil
+}
+
+// GetEntries is like GetEntriesContext but uses context.Background().
+func (p *PlaylistsAPI) GetEntries(playlistID string) ([]DetailedItem, error) {
+	return p.GetEntriesContext(context.Background(), playlistID)
+}
+
+// AddContext appends items to the end of a playlist
+func (p *PlaylistsAPI) AddContext(ctx context.Context, playlistID string, itemIDs ...string) error {
+	query := url.Values{}
+	query.Set("Ids", strings.Join(itemIDs, ","))
+	query.Set("UserId", p.client.config.UserID)
+	_, err := p.send(ctx, "POST", "/Playlists/"+playlistID+"/Items", query, nil)
+	return err
+}
+
+// Add is like AddContext but uses context.Background().
+func (p *PlaylistsAPI) Add(playlistID string, itemIDs ...string) error {
+	return p.AddContext(context.Background(), playlistID, itemIDs...)
+}
+
+// RemoveContext removes entries, identified by their PlaylistItemID, from a playlist
+func (p *PlaylistsAPI) RemoveContext(ctx context.Context, playlistID string, entryIDs ...string) error {
+	query := url.Values{}
+	query.Set("EntryIds", strings.Join(entryIDs, ","))
+	_, err := p.send(ctx, "DELETE", "/Playlists/"+playlistID+"/Items", query, nil)
+	return err
+}
+
+// Remove is like RemoveContext but uses context.Background().
+func (p *PlaylistsAPI) Remove(playlistID string, entryIDs ...string) error {
+	return p.RemoveContext(context.Background(), playlistID, entryIDs...)
+}
+
+// MoveContext moves an entry, identified by its PlaylistItemID, to a new zero-based
+// position in a playlist
+func (p *PlaylistsAPI) MoveContext(ctx context.Context, playlistID, entryID string, newIndex int) error {
+	path := fmt.Sprintf("/Playlists/%s/Items/%s/Move/%d", playlistID, entryID, newIndex)
+	_, err := p.send(ctx, "POST", path, nil, nil)
+	return err
+}
+
+// Move is like MoveContext but uses context.Background().
+func (p *PlaylistsAPI) Move(playlistID, entryID string, newIndex int) error {
+	return p.MoveContext(context.Background(), playlistID, entryID, newIndex)
+}
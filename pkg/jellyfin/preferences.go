@@ -0,0 +1,88 @@
+package jellyfin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// LanguagePreferences are the audio and subtitle languages to play, most preferred first
+type LanguagePreferences struct {
+	Audio        []string
+	Subtitle     []string
+	SubtitleMode string // one of the SubtitleMode constants, empty if unknown
+}
+
+// GetUserConfigurationContext returns the playback settings of the user on the server.
+// They are fetched once per user and then cached.
+func (p *PlaybackAPI) GetUserConfigurationContext(ctx context.Context) (*UserConfiguration, error) {
+	if !p.client.IsAuthenticated() {
+		return nil, fmt.Errorf("client is not authenticated")
+	}
+
+	userID := p.client.config.UserID
+	p.mu.Lock()
+	if p.userConfig != nil && p.userConfigID == userID {
+		config := *p.userConfig
+		p.mu.Unlock()
+		return &config, nil
+	}
+	p.mu.Unlock()
+
+	var user UserInfo
+	if err := p.client.doRequestDecode(ctx, "GET", p.client.config.ServerURL+"/Users/"+userID, nil, &user); err != nil {
+		return nil, fmt.Errorf("failed to get user configuration: %w", err)
+	}
+
+	p.mu.Lock()
+	p.userConfig = &user.Configuration
+	p.userConfigID = userID
+	p.mu.Unlock()
+	config := user.Configuration
+	return &config, nil
+}
+
+// GetUserConfiguration is like GetUserConfigurationContext but uses context.Background().
+func (p *PlaybackAPI) GetUserConfiguration() (*UserConfiguration, error) {
+	return p.GetUserConfigurationContext(context.Background())
+}
+
+// GetLanguagePreferencesContext returns the languages to play: those of the
+// configuration first, then the ones of the user on the server. The subtitle language
+// of the user only counts in the modes that show it (Always and Smart). Offline, or
+// when the server can't tell, only the configured languages are returned.
+func (p *PlaybackAPI) GetLanguagePreferencesContext(ctx context.Context) LanguagePreferences {
+	prefs := LanguagePreferences{
+		Audio:    slices.Clone(p.client.config.AudioLanguages),
+		Subtitle: slices.Clone(p.client.config.SubtitleLanguages),
+	}
+	if p.client.IsOfflineMode() {
+		return prefs
+	}
+
+	user, err := p.GetUserConfigurationContext(ctx)
+	if err != nil {
+		return prefs
+	}
+	prefs.Audio = appendLanguage(prefs.Audio, user.AudioLanguagePreference)
+	if user.SubtitleMode == SubtitleModeAlways || user.SubtitleMode == SubtitleModeSmart {
+		prefs.Subtitle = appendLanguage(prefs.Subtitle, user.SubtitleLanguagePreference)
+	}
+	prefs.SubtitleMode = user.SubtitleMode
+	return prefs
+}
+
+// GetLanguagePreferences is like GetLanguagePreferencesContext but uses context.Background().
+func (p *PlaybackAPI) GetLanguagePreferences() LanguagePreferences {
+	return p.GetLanguagePreferencesContext(context.Background())
+}
+
+// appendLanguage adds a language to a list of preferences unless it is empty or listed
+func appendLanguage(languages []string, language string) []string {
+	language = strings.TrimSpace(language)
+	if language == "" || slices.ContainsFunc(languages, func(l string) bool { return strings.EqualFold(l, language) }) {
+		return languages
+	}
+	return append(languages, language)
+}
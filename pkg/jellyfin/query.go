@@ -0,0 +1,358 @@
+package jellyfin
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SortOrder is the direction in which query results are sorted
+type SortOrder string
+
+// Sort orders
+const (
+	SortAscending  SortOrder = "Ascending"
+	SortDescending SortOrder = "Descending"
+)
+
+// Common fields to sort query results by
+const (
+	SortByName            = "SortName"
+	SortByDateCreated     = "DateCreated"
+	SortByDatePlayed      = "DatePlayed"
+	SortByPremiereDate    = "PremiereDate"
+	SortByProductionYear  = "ProductionYear"
+	SortByCommunityRating = "CommunityRating"
+	SortByRuntime         = "Runtime"
+	SortByRandom          = "Random"
+	SortByAlbum           = "Album"
+	SortByDiscNumber      = "ParentIndexNumber"
+	SortByTrackNumber     = "IndexNumber"
+)
+
+// Common item types for IncludeItemTypes and ExcludeItemTypes
+const (
+	ItemTypeMovie   = "Movie"
+	ItemTypeSeries  = "Series"
+	ItemTypeSeason  = "Season"
+	ItemTypeEpisode = "Episode"
+	ItemTypeFolder  = "Folder"
+	ItemTypeBoxSet  = "BoxSet"
+	ItemTypeVideo   = "Video"
+
+	ItemTypeMusicArtist = "MusicArtist"
+	ItemTypeMusicAlbum  = "MusicAlbum"
+	ItemTypeAudio       = "Audio"
+	ItemTypePlaylist    = "Playlist"
+)
+
+// Field sets requested by the listing methods
+var (
+	folderFields  = []string{"BasicSyncInfo", "UserData", "DateCreated", "Genres", "SortName"}
+	sectionFields = []string{"BasicSyncInfo", "UserData", "DateCreated", "Genres", "SortName", "CanDelete", "PrimaryImageAspectRatio"}
+	episodeFields = []string{"BasicSyncInfo", "UserData", "SeriesInfo"}
+)
+
+// ItemsQuery describes a request for a list of items. Zero values are left out of the
+// request so the server defaults apply. Build one with NewItemsQuery and the With
+// methods, or fill in the fields directly.
+type ItemsQuery struct {
+	ParentID         string
+	UserID           string // filled in with the client's user when empty
+	SeasonID         string // only used when listing the episodes of a series
+	SearchTerm       string
+	Recursive        bool
+	SortBy           []string // SortBy constants or any other Jellyfin sort field
+	SortOrder        SortOrder
+	IncludeItemTypes []string
+	ExcludeItemTypes []string
+	ArtistIDs        []string // items by any of these artists, searched recursively
+	Genres           []string
+	Years            []int
+	OfficialRatings  []string
+	IsFavorite       *bool
+	IsPlayed         *bool
+	IsResumable      bool // only items with a saved playback position
+	IsHD             *bool
+	Is4K             *bool
+	MinDateCreated   time.Time // zero for any date
+	MinPremiereDate  time.Time // zero for no lower bound
+	MaxPremiereDate  time.Time // zero for no upper bound
+	NameStartsWith   string
+	Fields           []string // additional fields returned with each item
+	EnableImageTypes []string
+	ImageTypeLimit   int
+	StartIndex       int
+	Limit            int  // 0 for no limit
+	SkipTotalCount   bool // do not count the matching items, which is faster on large libraries
+}
+
+// NewItemsQuery creates an empty query
+func NewItemsQuery() *ItemsQuery {
+	return &ItemsQuery{}
+}
+
+// WithParent restricts the query to the direct children of an item
+func (q *ItemsQuery) WithParent(parentID string) *ItemsQuery {
+	q.ParentID = parentID
+	return q
+}
+
+// WithSearchTerm restricts the query to items matching a search term
+func (q *ItemsQuery) WithSearchTerm(term string) *ItemsQuery {
+	q.SearchTerm = term
+	return q
+}
+
+// WithRecursive sets whether items are searched through subfolders
+func (q *ItemsQuery) WithRecursive(recursive bool) *ItemsQuery {
+	q.Recursive = recursive
+	return q
+}
+
+// WithSort sorts the results by the given fields in the given order
+func (q *ItemsQuery) WithSort(order SortOrder, sortBy ...string) *ItemsQuery {
+	q.SortBy = sortBy
+	q.SortOrder = order
+	return q
+}
+
+// WithTypes restricts the query to the given item types
+func (q *ItemsQuery) WithTypes(types ...string) *ItemsQuery {
+	q.IncludeItemTypes = types
+	return q
+}
+
+// WithoutTypes excludes the given item types
+func (q *ItemsQuery) WithoutTypes(types ...string) *ItemsQuery {
+	q.ExcludeItemTypes = types
+	return q
+}
+
+// WithArtists restricts the query to items by any of the given artists
+func (q *ItemsQuery) WithArtists(artistIDs ...string) *ItemsQuery {
+	q.ArtistIDs = artistIDs
+	return q
+}
+
+// WithGenres restricts the query to items of any of the given genres
+func (q *ItemsQuery) WithGenres(genres ...string) *ItemsQuery {
+	q.Genres = genres
+	return q
+}
+
+// WithYears restricts the query to items released in any of the given years
+func (q *ItemsQuery) WithYears(years ...int) *ItemsQuery {
+	q.Years = years
+	return q
+}
+
+// WithOfficialRatings restricts the query to items with any of the given parental ratings
+func (q *ItemsQuery) WithOfficialRatings(ratings ...string) *ItemsQuery {
+	q.OfficialRatings = ratings
+	return q
+}
+
+// WithFavorite restricts the query to favorite or non-favorite items
+func (q *ItemsQuery) WithFavorite(favorite bool) *ItemsQuery {
+	q.IsFavorite = &favorite
+	return q
+}
+
+// WithPlayed restricts the query to played or unplayed items
+func (q *ItemsQuery) WithPlayed(played bool) *ItemsQuery {
+	q.IsPlayed = &played
+	return q
+}
+
+// WithResumable restricts the query to items that can be resumed
+func (q *ItemsQuery) WithResumable() *ItemsQuery {
+	q.IsResumable = true
+	return q
+}
+
+// WithHD restricts the query to HD or SD videos
+func (q *ItemsQuery) WithHD(hd bool) *ItemsQuery {
+	q.IsHD = &hd
+	return q
+}
+
+// With4K restricts the query to 4K or non-4K videos
+func (q *ItemsQuery) With4K(uhd bool) *ItemsQuery {
+	q.Is4K = &uhd
+	return q
+}
+
+// WithMinDateCreated restricts the query to items added to the library since t
+func (q *ItemsQuery) WithMinDateCreated(t time.Time) *ItemsQuery {
+	q.MinDateCreated = t
+	return q
+}
+
+// WithPremiereDates restricts the query to items released between from and to; a zero
+// time leaves that end of the range open
+func (q *ItemsQuery) WithPremiereDates(from, to time.Time) *ItemsQuery {
+	q.MinPremiereDate = from
+	q.MaxPremiereDate = to
+	return q
+}
+
+// WithNameStartsWith restricts the query to items whose sort name starts with prefix
+func (q *ItemsQuery) WithNameStartsWith(prefix string) *ItemsQuery {
+	q.NameStartsWith = prefix
+	return q
+}
+
+// WithFields sets the additional fields returned with each item. The list is copied
+// since callers pass the shared field lists.
+func (q *ItemsQuery) WithFields(fields ...string) *ItemsQuery {
+	q.Fields = slices.Clone(fields)
+	return q
+}
+
+// WithImages sets the image types returned with each item and how many of each
+func (q *ItemsQuery) WithImages(limit int, imageTypes ...string) *ItemsQuery {
+	q.EnableImageTypes = imageTypes
+	q.ImageTypeLimit = limit
+	return q
+}
+
+// WithPage returns at most limit items, starting at startIndex
+func (q *ItemsQuery) WithPage(startIndex, limit int) *ItemsQuery {
+	q.StartIndex = startIndex
+	q.Limit = limit
+	return q
+}
+
+// WithLimit returns at most limit items
+func (q *ItemsQuery) WithLimit(limit int) *ItemsQuery {
+	q.Limit = limit
+	return q
+}
+
+// WithoutTotalCount skips counting the matching items
+func (q *ItemsQuery) WithoutTotalCount() *ItemsQuery {
+	q.SkipTotalCount = true
+	return q
+}
+
+// Values encodes the query as URL parameters
+func (q *ItemsQuery) Values() url.Values {
+	v := url.Values{}
+	setString := func(key, value string) {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+	setList := func(key string, values []string, sep string) {
+		if len(values) > 0 {
+			v.Set(key, strings.Join(values, sep))
+		}
+	}
+	setBool := func(key string, value *bool) {
+		if value != nil {
+			v.Set(key, strconv.FormatBool(*value))
+		}
+	}
+	setInt := func(key string, value int) {
+		if value > 0 {
+			v.Set(key, strconv.Itoa(value))
+		}
+	}
+
+	setString("ParentId", q.ParentID)
+	setString("UserId", q.UserID)
+	setString("SeasonId", q.SeasonID)
+	setString("SearchTerm", q.SearchTerm)
+	if q.Recursive {
+		v.Set("Recursive", "true")
+	}
+	setList("SortBy", q.SortBy, ",")
+	setString("SortOrder", string(q.SortOrder))
+	setList("IncludeItemTypes", q.IncludeItemTypes, ",")
+	setList("ExcludeItemTypes", q.ExcludeItemTypes, ",")
+	setList("ArtistIds", q.ArtistIDs, ",")
+	setList("Genres", q.Genres, "|") // Genre names may contain commas
+	if len(q.Years) > 0 {
+		years := make([]string, len(q.Years))
+		for i, year := range q.Years {
+			years[i] = strconv.Itoa(year)
+		}
+		setList("Years", years, ",")
+	}
+	setList("OfficialRatings", q.OfficialRatings, "|")
+	setBool("IsFavorite", q.IsFavorite)
+	setBool("IsPlayed", q.IsPlayed)
+	if q.IsResumable {
+		v.Set("Filters", "IsResumable")
+	}
+	setBool("IsHd", q.IsHD)
+	setBool("Is4K", q.Is4K)
+	setTime := func(key string, value time.Time) {
+		if !value.IsZero() {
+			v.Set(key, value.UTC().Format(time.RFC3339))
+		}
+	}
+	setTime("MinDateCreated", q.MinDateCreated)
+	setTime("MinPremiereDate", q.MinPremiereDate)
+	setTime("MaxPremiereDate", q.MaxPremiereDate)
+	setString("NameStartsWith", q.NameStartsWith)
+	setList("Fields", q.Fields, ",")
+	setList("EnableImageTypes", q.EnableImageTypes, ",")
+	setInt("ImageTypeLimit", q.ImageTypeLimit)
+	setInt("StartIndex", q.StartIndex)
+	setInt("Limit", q.Limit)
+	if q.SkipTotalCount {
+		v.Set("EnableTotalRecordCount", "false")
+	}
+	return v
+}
+
+// queryURL builds the URL of an item listing endpoint, filling in the client's user
+func (c *Client) queryURL(path string, q *ItemsQuery) string {
+	values := q.Values()
+	if q.UserID == "" && c.config.UserID != "" {
+		values.Set("UserId", c.config.UserID)
+	}
+	return c.config.ServerURL + path + "?" + values.Encode()
+}
+
+// sectionQuery is the query shared by the home sections (resume, next up, recently added)
+func sectionQuery(limit int) *ItemsQuery {
+	return NewItemsQuery().
+		WithFields(sectionFields...).
+		WithImages(1, "Primary", "Backdrop", "Thumb").
+		WithLimit(limit).
+		WithoutTotalCount()
+}
+
+// NewFolderQuery creates a query listing the direct children of a folder, as GetContext does
+func NewFolderQuery(parentID string, includeFolders bool) *ItemsQuery {
+	q := NewItemsQuery().WithParent(parentID).WithFields(folderFields...)
+	if !includeFolders {
+		q.WithoutTypes(ItemTypeFolder)
+	}
+	return q
+}
+
+// NewAlbumTracksQuery creates a query listing the tracks of an album in disc and track order
+func NewAlbumTracksQuery(albumID string) *ItemsQuery {
+	return NewItemsQuery().
+		WithParent(albumID).
+		WithTypes(ItemTypeAudio).
+		WithFields(folderFields...).
+		WithSort(SortAscending, SortByDiscNumber, SortByTrackNumber, SortByName)
+}
+
+// NewArtistAlbumsQuery creates a query listing the albums of an artist, oldest first.
+// Artists are not the parents of their albums, so they are matched by artist.
+func NewArtistAlbumsQuery(artistID string) *ItemsQuery {
+	return NewItemsQuery().
+		WithArtists(artistID).
+		WithRecursive(true).
+		WithTypes(ItemTypeMusicAlbum).
+		WithFields(folderFields...).
+		WithSort(SortAscending, SortByProductionYear, SortByName)
+}
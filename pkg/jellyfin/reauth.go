@@ -0,0 +1,70 @@
+package jellyfin
+
+import (
+	"context"
+	"fmt"
+)
+
+// ReauthFunc restores the authentication of a client after the server rejected its
+// token. It returns an error if the client could not be authenticated again.
+type ReauthFunc func(ctx context.Context, c *Client) error
+
+// SetReauthFunc sets the hook run when a request fails with HTTP 401. The request is
+// retried once if the hook succeeds. A nil hook disables re-authentication.
+// Clients start with ReloadSession; BuildAndConnect installs InteractiveReauth.
+func (c *Client) SetReauthFunc(fn ReauthFunc) {
+	c.reauthMu.Lock()
+	defer c.reauthMu.Unlock()
+	c.reauth = fn
+}
+
+// reauthenticate runs the re-auth hook for a request sent with staleToken and reports
+// whether the request should be retried. Concurrent requests rejected with the same
+// token share a single re-authentication.
+func (c *Client) reauthenticate(ctx context.Context, staleToken string) bool {
+	c.reauthMu.Lock()
+	defer c.reauthMu.Unlock()
+
+	if ctx.Err() != nil {
+		return false
+	}
+	if c.config.AccessToken != "" && c.config.AccessToken != staleToken {
+		return true // Another request already re-authenticated
+	}
+	if c.reauth == nil {
+		return false
+	}
+	if err := c.reauth(ctx, c); err != nil {
+		return false
+	}
+	return c.IsAuthenticated()
+}
+
+// ReloadSession is the default re-auth hook. It picks up a session saved since the
+// client loaded its own, for example by a login in another jtui instance.
+func ReloadSession(ctx context.Context, c *Client) error {
+	staleToken := c.config.AccessToken
+	if err := c.Auth.LoadSession(); err != nil {
+		return err
+	}
+	if c.config.AccessToken == staleToken {
+		return fmt.Errorf("saved session is the rejected one: %w", ErrUnauthorized)
+	}
+	return nil
+}
+
+// InteractiveReauth reloads the saved session or, failing that, logs in again with
+// the configured method, which may prompt on the terminal, and saves the new session.
+func InteractiveReauth(ctx context.Context, c *Client) error {
+	if err := ReloadSession(ctx, c); err == nil {
+		return nil
+	}
+
+	c.config.AccessToken = ""
+	c.config.UserID = ""
+	if err := c.Auth.Authenticate(); err != nil {
+		return fmt.Errorf("re-authentication failed: %w", err)
+	}
+	c.Auth.SaveSession() // Ignore error - not critical
+	return nil
+}
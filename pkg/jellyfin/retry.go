@@ -0,0 +1,215 @@
+package jellyfin
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the server while its circuit breaker is open
+var ErrCircuitOpen = errors.New("server is not responding, requests are paused")
+
+// RetryPolicy controls how idempotent GET requests are retried after transient failures
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts including the first one, 1 disables retries
+	BaseDelay   time.Duration // delay before the first retry, doubled for every further retry
+	MaxDelay    time.Duration // upper bound of the delay between two attempts
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+	}
+}
+
+// backoff returns the delay before the retry following the given attempt (0-based),
+// with jitter so that clients do not retry in lockstep
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << attempt
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	half := delay / 2
+	if half <= 0 {
+		return max(delay, 0) // No delay configured, or a negative one
+	}
+	return half + rand.N(half+1)
+}
+
+// isTransient reports whether a failed request may succeed if sent again. It also
+// decides what counts as a failure for the circuit breaker.
+func isTransient(err error) bool {
+	if err == nil || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	return IsConnectionError(err)
+}
+
+// Circuit breaker defaults
+const (
+	DefaultBreakerThreshold = 5                // consecutive failures that open the circuit
+	DefaultBreakerCooldown  = 30 * time.Second // time before a trial request is let through
+)
+
+// CircuitState is the state of a server's circuit breaker
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // requests flow normally
+	CircuitOpen                         // requests fail fast with ErrCircuitOpen
+	CircuitHalfOpen                     // a single trial request decides whether to close again
+)
+
+// circuitBreaker tracks consecutive failures of one server
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	trial     bool // a half-open trial request is in flight
+	threshold int
+	cooldown  time.Duration
+}
+
+// breakers holds one circuit breaker per server URL, shared by all clients of that server
+var (
+	breakersMu sync.Mutex
+	breakers   = make(map[string]*circuitBreaker)
+)
+
+// breakerFor returns the circuit breaker of a server, applying the given settings
+func breakerFor(serverURL string, threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		threshold = DefaultBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = DefaultBreakerCooldown
+	}
+
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+	b, ok := breakers[serverURL]
+	if !ok {
+		b = &circuitBreaker{}
+		breakers[serverURL] = b
+	}
+	b.mu.Lock()
+	b.threshold, b.cooldown = threshold, cooldown
+	b.mu.Unlock()
+	return b
+}
+
+// allow reports whether a request may be sent. Once the cooldown has passed, an open
+// circuit lets a single trial request through.
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = CircuitHalfOpen
+		b.trial = true
+		return nil
+	case CircuitHalfOpen:
+		if b.trial {
+			return ErrCircuitOpen
+		}
+		b.trial = true
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a request sent after allow
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if !failed {
+		b.state = CircuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// release ends a request sent after allow without recording an outcome
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+func (b *circuitBreaker) status() (CircuitState, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state, b.failures
+}
+
+// CircuitState returns the state of the circuit breaker of the client's server
+func (c *Client) CircuitState() CircuitState {
+	state, _ := c.breaker.status()
+	return state
+}
+
+// IsDegraded reports whether the server has been failing recently: requests are being
+// retried or the circuit breaker has paused them
+func (c *Client) IsDegraded() bool {
+	state, failures := c.breaker.status()
+	return state != CircuitClosed || failures > 0
+}
+
+// sendWithRetry sends a request through the circuit breaker, retrying GETs that fail
+// transiently according to the client's retry policy
+func (c *Client) sendWithRetry(ctx context.Context, newRequest func() (*http.Request, error)) ([]byte, error) {
+	policy := c.config.Retry
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.breaker.allow(); err != nil {
+			return nil, err
+		}
+
+		body, err := c.sendOnce(req)
+		if ctx.Err() != nil {
+			c.breaker.release() // Cancelled by the caller, says nothing about the server
+			return nil, err
+		}
+		transient := isTransient(err)
+		c.breaker.record(transient)
+
+		if !transient || req.Method != http.MethodGet || attempt+1 >= policy.MaxAttempts {
+			return body, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(policy.backoff(attempt)):
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package jellyfin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns an authenticated client of a stub server with short retry delays
+func newTestClient(t *testing.T, handler http.HandlerFunc, threshold int, cooldown time.Duration) (*Client, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client := NewClient(&Config{
+		ServerURL:        server.URL,
+		AccessToken:      "token",
+		UserID:           "user",
+		Retry:            RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+		BreakerThreshold: threshold,
+		BreakerCooldown:  cooldown,
+	})
+	return client, server
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	var hits atomic.Int32
+	client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}, 10, time.Minute)
+
+	body, err := client.doRequest(context.Background(), http.MethodGet, server.URL+"/Items", nil)
+	if err != nil {
+		t.Fatalf("doRequest: %v", err)
+	}
+	if string(body) != "ok" {
+		t.Errorf("body = %q, want %q", body, "ok")
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("server hit %d times, want 3", n)
+	}
+	if client.CircuitState() != CircuitClosed {
+		t.Errorf("circuit state = %v, want closed", client.CircuitState())
+	}
+}
+
+func TestNoRetryOnClientError(t *testing.T) {
+	var hits atomic.Int32
+	client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}, 10, time.Minute)
+
+	_, err := client.doRequest(context.Background(), http.MethodGet, server.URL+"/Items/missing", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want a 404 APIError", err)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %d times, want 1", n)
+	}
+	if client.IsDegraded() {
+		t.Error("a client error degraded the client")
+	}
+}
+
+func TestNoRetryOnPost(t *testing.T) {
+	var hits atomic.Int32
+	client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}, 10, time.Minute)
+
+	if _, err := client.doRequest(context.Background(), http.MethodPost, server.URL+"/Sessions/Playing", nil); err == nil {
+		t.Fatal("doRequest succeeded, want an error")
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %d times, want 1", n)
+	}
+}
+
+func TestCircuitBreakerOpensAndCloses(t *testing.T) {
+	var hits atomic.Int32
+	var healthy atomic.Bool
+	client, server := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}, 3, 50*time.Millisecond)
+	get := func() error {
+		_, err := client.doRequest(context.Background(), http.MethodGet, server.URL+"/System/Info", nil)
+		return err
+	}
+
+	// Three failed attempts of one request reach the threshold
+	if err := get(); err == nil {
+		t.Fatal("request to a failing server succeeded")
+	}
+	if client.CircuitState() != CircuitOpen {
+		t.Fatalf("circuit state = %v, want open", client.CircuitState())
+	}
+
+	before := hits.Load()
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if hits.Load() != before {
+		t.Error("a request reached the server while the circuit was open")
+	}
+
+	healthy.Store(true)
+	time.Sleep(60 * time.Millisecond)
+	if err := get(); err != nil {
+		t.Fatalf("trial request after the cooldown: %v", err)
+	}
+	if client.CircuitState() != CircuitClosed || client.IsDegraded() {
+		t.Errorf("circuit state = %v, degraded = %v, want closed and healthy", client.CircuitState(), client.IsDegraded())
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}
+	for attempt, limit := range []time.Duration{100, 200, 300, 300} {
+		limit *= time.Millisecond
+		if d := policy.backoff(attempt); d < limit/2 || d > limit {
+			t.Errorf("backoff(%d) = %v, want between %v and %v", attempt, d, limit/2, limit)
+		}
+	}
+
+	for _, p := range []RetryPolicy{
+		{},
+		{BaseDelay: -time.Second, MaxDelay: -time.Second},
+		{BaseDelay: time.Second, MaxDelay: -time.Second},
+		{BaseDelay: time.Nanosecond, MaxDelay: time.Nanosecond},
+	} {
+		if d := p.backoff(1); d < 0 {
+			t.Errorf("backoff of %+v = %v, want no negative delay", p, d)
+		}
+	}
+}
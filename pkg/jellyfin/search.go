@@ -1,8 +1,9 @@
 package jellyfin
 
 import (
+	"context"
 	"fmt"
-	"net/url"
+	"slices"
 )
 
 // SearchAPI handles search-related operations
@@ -24,7 +25,7 @@ func NewSearchOptions(query string) *SearchOptions {
 		Query:     query,
 		Limit:     50,
 		Recursive: true,
-		Fields:    []string{"BasicSyncInfo", "PrimaryImageAspectRatio"},
+		Fields:    slices.Clone(sectionFields),
 	}
 }
 
@@ -34,14 +35,20 @@ func (s *SearchOptions) WithLimit(limit int) *SearchOptions {
 	return s
 }
 
+// WithFields sets the additional fields returned with each result
+func (s *SearchOptions) WithFields(fields ...string) *SearchOptions {
+	s.Fields = fields
+	return s
+}
+
 // WithRecursive sets whether to search recursively through subdirectories
 func (s *SearchOptions) WithRecursive(recursive bool) *SearchOptions {
 	s.Recursive = recursive
 	return s
 }
 
-// Items searches for items using the Jellyfin search API
-func (s *SearchAPI) Items(options *SearchOptions) ([]Item, error) {
+// ItemsContext searches for items using the Jellyfin search API
+func (s *SearchAPI) ItemsContext(ctx context.Context, options *SearchOptions) ([]Item, error) {
 	if !s.client.IsAuthenticated() {
 		return nil, fmt.Errorf("client is not authenticated")
 	}
@@ -54,24 +61,33 @@ func (s *SearchAPI) Items(options *SearchOptions) ([]Item, error) {
 		return nil, fmt.Errorf("search query cannot be empty")
 	}
 
-	searchURL := fmt.Sprintf(
-		"%s/Users/%s/Items?searchTerm=%s&Recursive=%t&Fields=BasicSyncInfo,UserData,CanDelete,PrimaryImageAspectRatio&EnableImageTypes=Primary,Backdrop,Thumb&EnableTotalRecordCount=false&ImageTypeLimit=1&Limit=%d",
-		s.client.config.ServerURL,
-		s.client.config.UserID,
-		url.QueryEscape(options.Query),
-		options.Recursive,
-		options.Limit,
-	)
+	q := NewItemsQuery().
+		WithSearchTerm(options.Query).
+		WithRecursive(options.Recursive).
+		WithFields(options.Fields...).
+		WithImages(1, "Primary", "Backdrop", "Thumb").
+		WithLimit(options.Limit).
+		WithoutTotalCount()
 
 	var response DetailedItemsResponse
-	if err := s.client.doRequestDecode("GET", searchURL, nil, &response); err != nil {
+	if err := s.client.doRequestDecode(ctx, "GET", s.client.queryURL("/Items", q), nil, &response); err != nil {
 		return nil, err
 	}
 
 	return toItems(response.Items), nil
 }
 
-// Quick performs a quick search with default options
+// Items is like ItemsContext but uses context.Background().
+func (s *SearchAPI) Items(options *SearchOptions) ([]Item, error) {
+	return s.ItemsContext(context.Background(), options)
+}
+
+// QuickContext performs a quick search with default options
+func (s *SearchAPI) QuickContext(ctx context.Context, query string) ([]Item, error) {
+	return s.ItemsContext(ctx, NewSearchOptions(query))
+}
+
+// Quick is like QuickContext but uses context.Background().
 func (s *SearchAPI) Quick(query string) ([]Item, error) {
-	return s.Items(NewSearchOptions(query))
+	return s.QuickContext(context.Background(), query)
 }
@@ -0,0 +1,87 @@
+package jellyfin
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Types of media segments
+const (
+	SegmentIntro   = "Intro"
+	SegmentOutro   = "Outro" // the credits
+	SegmentRecap   = "Recap"
+	SegmentPreview = "Preview"
+)
+
+// SegmentTypes are the types of media segments jtui acts on
+var SegmentTypes = []string{SegmentIntro, SegmentOutro, SegmentRecap, SegmentPreview}
+
+// What to do when playback enters a segment
+const (
+	SegmentActionAsk    = "ask"    // offer to skip it
+	SegmentActionSkip   = "skip"   // skip it right away
+	SegmentActionIgnore = "ignore" // play it
+)
+
+// MediaSegment is a part of an item, like its intro or its credits
+type MediaSegment struct {
+	ID         string `json:"Id"`
+	ItemID     string `json:"ItemId"`
+	Type       string `json:"Type"` // one of the Segment constants
+	StartTicks int64  `json:"StartTicks"`
+	EndTicks   int64  `json:"EndTicks"`
+}
+
+// Contains reports whether a position is within the segment
+func (s MediaSegment) Contains(positionTicks int64) bool {
+	return positionTicks >= s.StartTicks && positionTicks < s.EndTicks
+}
+
+// SegmentAction returns what to do when playback enters a segment of the given type,
+// SegmentActionAsk unless configured otherwise
+func (c *Config) SegmentAction(segmentType string) string {
+	if action, ok := c.SegmentActions[segmentType]; ok {
+		return action
+	}
+	return SegmentActionAsk
+}
+
+// ParseSegmentAction validates a segment action of the configuration
+func ParseSegmentAction(value string) (string, error) {
+	action := strings.ToLower(strings.TrimSpace(value))
+	if !slices.Contains([]string{SegmentActionAsk, SegmentActionSkip, SegmentActionIgnore}, action) {
+		return "", fmt.Errorf("unknown segment action %q (expected ask, skip or ignore)", value)
+	}
+	return action, nil
+}
+
+// GetMediaSegmentsContext returns the intro, credits, recap and preview segments of an
+// item, sorted by start position. Servers older than Jellyfin 10.10 have no segments.
+func (p *PlaybackAPI) GetMediaSegmentsContext(ctx context.Context, itemID string) ([]MediaSegment, error) {
+	if !p.client.IsAuthenticated() {
+		return nil, fmt.Errorf("client is not authenticated")
+	}
+
+	query := url.Values{}
+	query.Set("includeSegmentTypes", strings.Join(SegmentTypes, ","))
+	var response struct {
+		Items []MediaSegment `json:"Items"`
+	}
+	u := fmt.Sprintf("%s/MediaSegments/%s?%s", p.client.config.ServerURL, itemID, query.Encode())
+	if err := p.client.doRequestDecode(ctx, "GET", u, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to get media segments: %w", err)
+	}
+	slices.SortFunc(response.Items, func(a, b MediaSegment) int {
+		return cmp.Compare(a.StartTicks, b.StartTicks)
+	})
+	return response.Items, nil
+}
+
+// GetMediaSegments is like GetMediaSegmentsContext but uses context.Background().
+func (p *PlaybackAPI) GetMediaSegments(itemID string) ([]MediaSegment, error) {
+	return p.GetMediaSegmentsContext(context.Background(), itemID)
+}
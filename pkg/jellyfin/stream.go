@@ -0,0 +1,234 @@
+package jellyfin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Play methods reported to the server
+const (
+	PlayMethodDirectPlay   = "DirectPlay"   // the original file, as is
+	PlayMethodDirectStream = "DirectStream" // the original streams, possibly remuxed
+	PlayMethodTranscode    = "Transcode"    // streams re-encoded by the server
+)
+
+// StreamInfo is where and how to play an item, as negotiated with the server
+type StreamInfo struct {
+	ItemID        string
+	URL           string
+	IsLocal       bool // URL is the path of a downloaded file
+	PlayMethod    string
+	MediaSourceID string
+	PlaySessionID string
+	LiveStreamID  string // set for live streams, which must be closed once played
+	// SubtitleFiles are the URLs or paths of external subtitles to load along the stream
+	SubtitleFiles []string
+}
+
+// StreamOptions adjusts the negotiation of a stream
+type StreamOptions struct {
+	MediaSourceID string // version of the item to play, the server's choice if empty
+}
+
+// deviceProfile tells the server what jtui can play
+type deviceProfile struct {
+	Name                string               `json:"Name"`
+	MaxStreamingBitrate int64                `json:"MaxStreamingBitrate,omitempty"`
+	DirectPlayProfiles  []directPlayProfile  `json:"DirectPlayProfiles"`
+	TranscodingProfiles []transcodingProfile `json:"TranscodingProfiles"`
+	SubtitleProfiles    []subtitleProfile    `json:"SubtitleProfiles"`
+}
+
+// directPlayProfile accepts items of a type; with no container or codec, any of them
+type directPlayProfile struct {
+	Type string `json:"Type"`
+}
+
+type transcodingProfile struct {
+	Type             string `json:"Type"`
+	Container        string `json:"Container"`
+	Protocol         string `json:"Protocol,omitempty"`
+	VideoCodec       string `json:"VideoCodec,omitempty"`
+	AudioCodec       string `json:"AudioCodec"`
+	Context          string `json:"Context"`
+	MaxAudioChannels string `json:"MaxAudioChannels,omitempty"`
+}
+
+type subtitleProfile struct {
+	Format string `json:"Format"`
+	Method string `json:"Method"`
+}
+
+// playbackInfoRequest is the body of a PlaybackInfo request
+type playbackInfoRequest struct {
+	UserID              string         `json:"UserId"`
+	MaxStreamingBitrate int64          `json:"MaxStreamingBitrate,omitempty"`
+	MediaSourceID       string         `json:"MediaSourceId,omitempty"`
+	DeviceProfile       *deviceProfile `json:"DeviceProfile,omitempty"`
+	IsPlayback          bool           `json:"IsPlayback"`
+	AutoOpenLiveStream  bool           `json:"AutoOpenLiveStream,omitempty"`
+}
+
+// mpvDeviceProfile describes mpv: it plays about any container and codec, so every item
+// can be played directly, and transcodes are requested as HLS with H.264 and AAC.
+func mpvDeviceProfile(maxBitrate int64) *deviceProfile {
+	profile := &deviceProfile{
+		Name:                "jtui (mpv)",
+		MaxStreamingBitrate: maxBitrate,
+		DirectPlayProfiles:  []directPlayProfile{{Type: "Video"}, {Type: "Audio"}},
+		TranscodingProfiles: []transcodingProfile{
+			{
+				Type:             "Video",
+				Container:        "ts",
+				Protocol:         "hls",
+				VideoCodec:       "h264",
+				AudioCodec:       "aac,mp3",
+				Context:          "Streaming",
+				MaxAudioChannels: "6",
+			},
+			{Type: "Audio", Container: "mp3", AudioCodec: "mp3", Context: "Streaming"},
+		},
+	}
+	for _, format := range []string{"srt", "subrip", "ass", "ssa", "vtt", "webvtt", "pgs", "pgssub", "dvdsub", "dvbsub"} {
+		profile.SubtitleProfiles = append(profile.SubtitleProfiles, subtitleProfile{Format: format, Method: "Embed"})
+	}
+	return profile
+}
+
+// getPlaybackInfo asks the server how it can stream an item
+func (p *PlaybackAPI) getPlaybackInfo(ctx context.Context, itemID string, request playbackInfoRequest) (*PlaybackInfoResponse, error) {
+	if !p.client.IsAuthenticated() {
+		return nil, fmt.Errorf("client is not authenticated")
+	}
+
+	request.UserID = p.client.config.UserID
+	request.IsPlayback = true
+	request.MaxStreamingBitrate = p.client.config.MaxBitrate
+	request.DeviceProfile = mpvDeviceProfile(p.client.config.MaxBitrate)
+
+	url := fmt.Sprintf("%s/Items/%s/PlaybackInfo?UserId=%s", p.client.config.ServerURL, itemID, p.client.config.UserID)
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal playback info request: %w", err)
+	}
+
+	var info PlaybackInfoResponse
+	if err := p.client.doRequestDecode(ctx, "POST", url, bytes.NewBuffer(jsonData), &info); err != nil {
+		return nil, err
+	}
+	if info.ErrorCode != "" {
+		return nil, fmt.Errorf("server cannot play item: %s", info.ErrorCode)
+	}
+	if len(info.MediaSources) == 0 {
+		return nil, fmt.Errorf("server returned no media source")
+	}
+	return &info, nil
+}
+
+// negotiate requests a stream of an item and picks how to play it
+func (p *PlaybackAPI) negotiate(ctx context.Context, itemID string, isAudio bool, request playbackInfoRequest) (*StreamInfo, error) {
+	info, err := p.getPlaybackInfo(ctx, itemID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	source := info.MediaSources[0]
+	if request.MediaSourceID != "" {
+		for _, s := range info.MediaSources {
+			if s.ID == request.MediaSourceID {
+				source = s
+				break
+			}
+		}
+	}
+	return p.chooseStream(itemID, isAudio, info.PlaySessionID, source)
+}
+
+// chooseStream picks the play method of a media source: the original file or streams
+// when the source fits within the configured max bitrate, else a transcode. A source
+// the server won't transcode is streamed directly even if it exceeds the bitrate.
+func (p *PlaybackAPI) chooseStream(itemID string, isAudio bool, playSessionID string, source MediaSourceInfo) (*StreamInfo, error) {
+	stream := &StreamInfo{
+		ItemID:        itemID,
+		MediaSourceID: source.ID,
+		PlaySessionID: playSessionID,
+		LiveStreamID:  source.LiveStreamID,
+		SubtitleFiles: p.subtitleURLs(itemID, source),
+	}
+
+	maxBitrate := p.client.config.MaxBitrate
+	withinLimit := maxBitrate == 0 || source.Bitrate == 0 || source.Bitrate <= maxBitrate
+	direct := source.SupportsDirectPlay || source.SupportsDirectStream
+
+	switch {
+	case direct && (withinLimit || source.TranscodingURL == ""):
+		stream.PlayMethod = PlayMethodDirectStream
+		if source.SupportsDirectPlay {
+			stream.PlayMethod = PlayMethodDirectPlay
+		}
+		stream.URL = p.staticStreamURL(itemID, isAudio, stream)
+	case source.TranscodingURL != "":
+		stream.PlayMethod = PlayMethodTranscode
+		stream.URL = p.client.config.ServerURL + source.TranscodingURL
+		if !strings.Contains(source.TranscodingURL, "api_key=") {
+			stream.URL += "&api_key=" + p.client.config.AccessToken
+		}
+	default:
+		return nil, fmt.Errorf("server offers no way to stream item %s", itemID)
+	}
+	return stream, nil
+}
+
+// staticStreamURL is the URL of the original file of a media source
+func (p *PlaybackAPI) staticStreamURL(itemID string, isAudio bool, stream *StreamInfo) string {
+	query := url.Values{}
+	query.Set("Static", "true")
+	query.Set("MediaSourceId", stream.MediaSourceID)
+	query.Set("PlaySessionId", stream.PlaySessionID)
+	query.Set("DeviceId", p.client.config.DeviceID)
+	query.Set("api_key", p.client.config.AccessToken)
+	if stream.LiveStreamID != "" {
+		query.Set("LiveStreamId", stream.LiveStreamID)
+	}
+	kind := "Videos"
+	if isAudio {
+		kind = "Audio"
+	}
+	return fmt.Sprintf("%s/%s/%s/stream?%s", p.client.config.ServerURL, kind, itemID, query.Encode())
+}
+
+// GetPlaybackStreamContext returns how to play an item: its downloaded file if there
+// is one, else a stream negotiated with the server through PlaybackInfo. item may be
+// nil when its details are unknown.
+func (p *PlaybackAPI) GetPlaybackStreamContext(
+	ctx context.Context, itemID string, item *DetailedItem, opts StreamOptions,
+) (*StreamInfo, error) {
+	if item != nil {
+		if localPath, isLocal := p.client.Download.GetLocalVideoPath(item); isLocal {
+			return &StreamInfo{
+				ItemID:        itemID,
+				URL:           localPath,
+				IsLocal:       true,
+				PlayMethod:    PlayMethodDirectPlay,
+				SubtitleFiles: p.client.Download.LocalSubtitles(localPath),
+			}, nil
+		}
+	}
+
+	isAudio := item != nil && item.IsAudio()
+	stream, err := p.negotiate(ctx, itemID, isAudio, playbackInfoRequest{MediaSourceID: opts.MediaSourceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to negotiate stream: %w", err)
+	}
+	return stream, nil
+}
+
+// GetPlaybackStream is like GetPlaybackStreamContext but uses context.Background().
+func (p *PlaybackAPI) GetPlaybackStream(itemID string, item *DetailedItem, opts StreamOptions) (*StreamInfo, error) {
+	return p.GetPlaybackStreamContext(context.Background(), itemID, item, opts)
+}
@@ -0,0 +1,168 @@
+package jellyfin
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// subtitleExtensions are the extensions of subtitle files saved next to downloads
+var subtitleExtensions = map[string]bool{
+	".srt": true, ".ass": true, ".ssa": true, ".vtt": true, ".sub": true,
+}
+
+// imageSubtitleCodecs are the bitmap subtitle formats. mpv can't load them from a lone
+// file and the server can't convert them to text, so they are not downloaded; files
+// saved by older versions are still removed with the download
+var imageSubtitleCodecs = map[string]bool{
+	"pgssub": true, "dvdsub": true, "dvbsub": true, "xsub": true,
+}
+
+// subtitleFormat returns the file format of a subtitle stream from its codec
+func subtitleFormat(codec string) string {
+	switch codec = strings.ToLower(codec); codec {
+	case "", "subrip":
+		return "srt"
+	case "webvtt":
+		return "vtt"
+	}
+	return codec
+}
+
+// externalSubtitles returns the subtitle streams stored as separate files on the server
+func externalSubtitles(streams []MediaStream) []MediaStream {
+	var subtitles []MediaStream
+	for _, stream := range streams {
+		if stream.Type == StreamTypeSubtitle && stream.IsExternal {
+			subtitles = append(subtitles, stream)
+		}
+	}
+	return subtitles
+}
+
+// GetSubtitleURL returns the URL of a subtitle stream of a media source, in its own format
+func (p *PlaybackAPI) GetSubtitleURL(itemID, mediaSourceID string, stream MediaStream) string {
+	return fmt.Sprintf("%s/Videos/%s/%s/Subtitles/%d/Stream.%s?api_key=%s",
+		p.client.config.ServerURL, itemID, mediaSourceID, stream.Index, subtitleFormat(stream.Codec), p.client.config.AccessToken)
+}
+
+// subtitleURLs returns the URLs of the external subtitles of a media source
+func (p *PlaybackAPI) subtitleURLs(itemID string, source MediaSourceInfo) []string {
+	var urls []string
+	for _, stream := range externalSubtitles(source.MediaStreams) {
+		urls = append(urls, p.GetSubtitleURL(itemID, source.ID, stream))
+	}
+	return urls
+}
+
+// subtitlePath returns where a subtitle stream of a downloaded video is saved:
+// "<video>.<index>.<language>.<format>", which mpv recognizes as belonging to the video
+func subtitlePath(videoPath string, stream MediaStream) string {
+	base := strings.TrimSuffix(videoPath, filepath.Ext(videoPath))
+	name := fmt.Sprintf("%s.%d", base, stream.Index)
+	if stream.Language != "" {
+		name += "." + stream.Language
+	}
+	return name + "." + subtitleFormat(stream.Codec)
+}
+
+// LocalSubtitles returns the subtitle files saved next to a downloaded video
+func (d *DownloadAPI) LocalSubtitles(videoPath string) []string {
+	return savedSubtitles(videoPath, func(ext string) bool { return subtitleExtensions[ext] })
+}
+
+// savedSubtitles returns the files next to a video named after it whose extension,
+// lowercased and with its dot, is accepted by match
+func savedSubtitles(videoPath string, match func(ext string) bool) []string {
+	dir := filepath.Dir(videoPath)
+	prefix := strings.TrimSuffix(filepath.Base(videoPath), filepath.Ext(videoPath)) + "."
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, prefix) && match(strings.ToLower(filepath.Ext(name))) {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	return paths
+}
+
+// localSubtitleStreams describes subtitle files saved next to a video, for downloads
+// without a metadata sidecar
+func localSubtitleStreams(paths []string) []MediaStream {
+	streams := make([]MediaStream, 0, len(paths))
+	for i, path := range paths {
+		ext := filepath.Ext(path)
+		stream := MediaStream{
+			Index:      i,
+			Type:       StreamTypeSubtitle,
+			Codec:      strings.TrimPrefix(strings.ToLower(ext), "."),
+			IsExternal: true,
+		}
+		// The language is the last part of the name before the format, e.g. "eng", and
+		// not the index of the stream
+		if language := filepath.Ext(strings.TrimSuffix(path, ext)); isLanguageTag(strings.TrimPrefix(language, ".")) {
+			stream.Language = language[1:]
+		}
+		streams = append(streams, stream)
+	}
+	return streams
+}
+
+// isLanguageTag reports whether s looks like an ISO 639 language code, e.g. "en" or "eng"
+func isLanguageTag(s string) bool {
+	return (len(s) == 2 || len(s) == 3) && strings.IndexFunc(s, func(r rune) bool { return !unicode.IsLetter(r) }) < 0
+}
+
+// downloadSubtitles saves the external subtitles of the downloaded version of an item
+// next to its video. Subtitles are a bonus: a missing one doesn't fail the download.
+func (d *DownloadAPI) downloadSubtitles(item *DetailedItem, videoPath string) {
+	sourceID, streams := item.GetID(), item.MediaStreams
+	for _, source := range item.MediaSources {
+		if source.ID == item.DownloadedSourceID || item.DownloadedSourceID == "" {
+			sourceID, streams = source.ID, source.MediaStreams
+			break
+		}
+	}
+
+	for _, stream := range externalSubtitles(streams) {
+		if imageSubtitleCodecs[strings.ToLower(stream.Codec)] {
+			continue
+		}
+		_ = d.downloadFile(d.client.Playback.GetSubtitleURL(item.GetID(), sourceID, stream), subtitlePath(videoPath, stream))
+	}
+}
+
+// downloadFile saves the body of a GET request to a file
+func (d *DownloadAPI) downloadFile(url, filePath string) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := d.downloadHTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", filepath.Base(filePath), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(req, resp, nil)
+	}
+
+	out, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", filePath, err)
+	}
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		out.Close()
+		os.Remove(filePath)
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return out.Close()
+}
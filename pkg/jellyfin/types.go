@@ -3,6 +3,7 @@ package jellyfin
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // Item represents a Jellyfin media item interface
@@ -35,11 +36,17 @@ func (s SimpleItem) GetIsFolder() bool {
 // DetailedItem represents a Jellyfin item with additional metadata
 type DetailedItem struct {
 	SimpleItem
-	Overview       string   `json:"Overview"`
-	ProductionYear int      `json:"ProductionYear"`
-	RunTimeTicks   int64    `json:"RunTimeTicks"`
-	Genres         []string `json:"Genres"`
-	Studios        []struct {
+	SortName        string    `json:"SortName,omitempty"` // name the server sorts by, e.g. without "The"
+	Overview        string    `json:"Overview"`
+	ProductionYear  int       `json:"ProductionYear"`
+	RunTimeTicks    int64     `json:"RunTimeTicks"`
+	CommunityRating float64   `json:"CommunityRating,omitempty"`
+	OfficialRating  string    `json:"OfficialRating,omitempty"`
+	DateCreated     time.Time `json:"DateCreated,omitempty"`
+	Width           int       `json:"Width,omitempty"`
+	Height          int       `json:"Height,omitempty"`
+	Genres          []string  `json:"Genres"`
+	Studios         []struct {
 		Name string `json:"Name"`
 	} `json:"Studios"`
 	ImageTags struct {
@@ -47,19 +54,41 @@ type DetailedItem struct {
 	} `json:"ImageTags"`
 	BackdropImageTags []string `json:"BackdropImageTags"`
 	UserData          struct {
-		PlaybackPositionTicks int64   `json:"PlaybackPositionTicks"`
-		PlayCount             int     `json:"PlayCount"`
-		IsFavorite            bool    `json:"IsFavorite"`
-		Played                bool    `json:"Played"`
-		PlayedPercentage      float64 `json:"PlayedPercentage"`
-		UnplayedItemCount     int     `json:"UnplayedItemCount"`
+		PlaybackPositionTicks int64     `json:"PlaybackPositionTicks"`
+		PlayCount             int       `json:"PlayCount"`
+		IsFavorite            bool      `json:"IsFavorite"`
+		Played                bool      `json:"Played"`
+		PlayedPercentage      float64   `json:"PlayedPercentage"`
+		UnplayedItemCount     int       `json:"UnplayedItemCount"`
+		LastPlayedDate        time.Time `json:"LastPlayedDate,omitempty"`
 	} `json:"UserData"`
 
 	// Series/Season information
 	SeriesName        string `json:"SeriesName,omitempty"`
+	SeriesID          string `json:"SeriesId,omitempty"`
 	SeasonName        string `json:"SeasonName,omitempty"`
-	ParentIndexNumber int    `json:"ParentIndexNumber,omitempty"`
-	IndexNumber       int    `json:"IndexNumber,omitempty"`
+	ParentIndexNumber int    `json:"ParentIndexNumber,omitempty"` // season, or disc of a track
+	IndexNumber       int    `json:"IndexNumber,omitempty"`       // episode, or track number
+
+	// Music information
+	Album       string   `json:"Album,omitempty"`
+	AlbumID     string   `json:"AlbumId,omitempty"`
+	AlbumArtist string   `json:"AlbumArtist,omitempty"`
+	Artists     []string `json:"Artists,omitempty"`
+	Container   string   `json:"Container,omitempty"` // file format, e.g. "flac" or "mov,mp4"
+
+	// PlaylistItemID identifies the entry of the item when listed within a playlist
+	PlaylistItemID string `json:"PlaylistItemId,omitempty"`
+
+	// Chapters lists the chapters of a video, sorted by start position
+	Chapters []Chapter `json:"Chapters,omitempty"`
+	// MediaStreams lists the video, audio and subtitle streams of the default version
+	MediaStreams []MediaStream `json:"MediaStreams,omitempty"`
+	// MediaSources lists the versions of the item, e.g. a 4K and a 1080p file of a movie
+	MediaSources []MediaSourceInfo `json:"MediaSources,omitempty"`
+	// DownloadedSourceID is the version to download, or the one downloaded once recorded
+	// in the sidecar; empty for the server's default version
+	DownloadedSourceID string `json:"DownloadedSourceId,omitempty"`
 }
 
 // Additional methods for DetailedItem
@@ -98,6 +127,29 @@ func (d DetailedItem) GetStudio() string {
 	return d.Studios[0].Name
 }
 
+// IsHD reports whether the video is at least 720p. Zero dimensions mean unknown.
+func (d DetailedItem) IsHD() bool {
+	return d.Width >= 1260 || d.Height >= 700
+}
+
+// Is4K reports whether the video is at least 2160p. Zero dimensions mean unknown.
+func (d DetailedItem) Is4K() bool {
+	return d.Width >= 3800 || d.Height >= 2000
+}
+
+// IsAudio reports whether the item is a music track
+func (d DetailedItem) IsAudio() bool {
+	return d.Type == ItemTypeAudio
+}
+
+// GetArtist returns the album artist of a track or album, falling back to its artists
+func (d DetailedItem) GetArtist() string {
+	if d.AlbumArtist != "" {
+		return d.AlbumArtist
+	}
+	return strings.Join(d.Artists, ", ")
+}
+
 func (d DetailedItem) HasPrimaryImage() bool {
 	return d.ImageTags.Primary != ""
 }
@@ -140,9 +192,10 @@ func (d DetailedItem) GetEpisodeNumber() int {
 
 // QuickConnectData holds Quick Connect authentication data
 type QuickConnectData struct {
-	Code     string `json:"code"`
-	Secret   string `json:"secret"`
-	DeviceID string `json:"DeviceId"`
+	Code      string    `json:"code"`
+	Secret    string    `json:"secret"`
+	DeviceID  string    `json:"DeviceId"`
+	ExpiresAt time.Time `json:"-"`
 }
 
 // SessionData holds session information for persistence
@@ -158,7 +211,16 @@ type ItemsResponse struct {
 
 // DetailedItemsResponse represents the response from detailed Items API endpoints
 type DetailedItemsResponse struct {
-	Items []DetailedItem `json:"Items"`
+	Items            []DetailedItem `json:"Items"`
+	TotalRecordCount int            `json:"TotalRecordCount"`
+}
+
+// FilterOptions lists the genres, parental ratings and years found among items,
+// to choose filters from
+type FilterOptions struct {
+	Genres          []string `json:"Genres"`
+	OfficialRatings []string `json:"OfficialRatings"`
+	Years           []int    `json:"Years"`
 }
 
 // PlaybackInfo holds playback session information
@@ -166,17 +228,143 @@ type PlaybackInfo struct {
 	ItemID        string `json:"ItemId"`
 	SessionID     string `json:"SessionId"`
 	MediaSourceID string `json:"MediaSourceId"`
+	PlaySessionID string `json:"PlaySessionId,omitempty"`
+	LiveStreamID  string `json:"LiveStreamId,omitempty"`
 	PositionTicks int64  `json:"PositionTicks,omitempty"`
 	CanSeek       bool   `json:"CanSeek,omitempty"`
 	PlayMethod    string `json:"PlayMethod,omitempty"`
 }
 
+// PlaybackInfoResponse describes how the server can stream an item
+type PlaybackInfoResponse struct {
+	MediaSources  []MediaSourceInfo `json:"MediaSources"`
+	PlaySessionID string            `json:"PlaySessionId"`
+	ErrorCode     string            `json:"ErrorCode,omitempty"`
+}
+
+// MediaSourceInfo is a version of an item the server can stream
+type MediaSourceInfo struct {
+	ID                   string        `json:"Id"`
+	Name                 string        `json:"Name,omitempty"`
+	Container            string        `json:"Container,omitempty"`
+	Bitrate              int64         `json:"Bitrate,omitempty"`      // bits per second
+	LiveStreamID         string        `json:"LiveStreamId,omitempty"` // set once a live stream is opened
+	SupportsDirectPlay   bool          `json:"SupportsDirectPlay"`
+	SupportsDirectStream bool          `json:"SupportsDirectStream"`
+	SupportsTranscoding  bool          `json:"SupportsTranscoding"`
+	TranscodingURL       string        `json:"TranscodingUrl,omitempty"` // relative to the server URL
+	Size                 int64         `json:"Size,omitempty"`           // bytes
+	MediaStreams         []MediaStream `json:"MediaStreams,omitempty"`
+}
+
+// Chapter is a chapter of a video
+type Chapter struct {
+	Name               string `json:"Name"`
+	StartPositionTicks int64  `json:"StartPositionTicks"`
+}
+
+// ChapterAt returns the index of the chapter playing at the given position, -1 if the
+// item has no chapter there
+func (d DetailedItem) ChapterAt(positionTicks int64) int {
+	index := -1
+	for i, chapter := range d.Chapters {
+		if chapter.StartPositionTicks > positionTicks {
+			break
+		}
+		index = i
+	}
+	return index
+}
+
+// Types of media streams
+const (
+	StreamTypeVideo    = "Video"
+	StreamTypeAudio    = "Audio"
+	StreamTypeSubtitle = "Subtitle"
+)
+
+// MediaStream is a video, audio or subtitle stream of a media source
+type MediaStream struct {
+	Index         int    `json:"Index"`
+	Type          string `json:"Type"` // one of the StreamType constants
+	Codec         string `json:"Codec,omitempty"`
+	Language      string `json:"Language,omitempty"` // ISO 639-2 code, e.g. "jpn"
+	Title         string `json:"Title,omitempty"`
+	DisplayTitle  string `json:"DisplayTitle,omitempty"` // e.g. "Japanese - AAC - Stereo - Default"
+	Width         int    `json:"Width,omitempty"`
+	Height        int    `json:"Height,omitempty"`
+	Channels      int    `json:"Channels,omitempty"`
+	ChannelLayout string `json:"ChannelLayout,omitempty"`
+	IsDefault     bool   `json:"IsDefault,omitempty"`
+	IsForced      bool   `json:"IsForced,omitempty"`
+	IsExternal    bool   `json:"IsExternal,omitempty"`
+}
+
+// StreamsOfType returns the streams of the item of the given type, in order
+func (d DetailedItem) StreamsOfType(streamType string) []MediaStream {
+	var streams []MediaStream
+	for _, stream := range d.MediaStreams {
+		if stream.Type == streamType {
+			streams = append(streams, stream)
+		}
+	}
+	return streams
+}
+
+// VideoStream returns the first video stream of the source, nil if there is none
+func (s MediaSourceInfo) VideoStream() *MediaStream {
+	for i := range s.MediaStreams {
+		if s.MediaStreams[i].Type == StreamTypeVideo {
+			return &s.MediaStreams[i]
+		}
+	}
+	return nil
+}
+
+// Resolution returns the usual name of the video resolution of the source, e.g. "4K"
+// or "1080p", or an empty string for sources without video
+func (s MediaSourceInfo) Resolution() string {
+	video := s.VideoStream()
+	if video == nil || video.Height == 0 {
+		return ""
+	}
+	// Widescreen films are letterboxed: the width tells a 4K or 1080p file apart better
+	switch {
+	case video.Width >= 3800:
+		return "4K"
+	case video.Width >= 2500:
+		return "1440p"
+	case video.Width >= 1900:
+		return "1080p"
+	case video.Width >= 1260:
+		return "720p"
+	}
+	return fmt.Sprintf("%dp", video.Height)
+}
+
 // UserInfo represents user information
 type UserInfo struct {
-	ID   string `json:"Id"`
-	Name string `json:"Name"`
+	ID            string            `json:"Id"`
+	Name          string            `json:"Name"`
+	Configuration UserConfiguration `json:"Configuration"`
+}
+
+// UserConfiguration holds the playback settings of a user on the server
+type UserConfiguration struct {
+	AudioLanguagePreference    string `json:"AudioLanguagePreference,omitempty"`
+	SubtitleLanguagePreference string `json:"SubtitleLanguagePreference,omitempty"`
+	SubtitleMode               string `json:"SubtitleMode,omitempty"` // one of the SubtitleMode constants
 }
 
+// Subtitle modes of a user configuration
+const (
+	SubtitleModeDefault    = "Default"    // subtitles flagged default or forced
+	SubtitleModeAlways     = "Always"     // subtitles in the preferred language
+	SubtitleModeOnlyForced = "OnlyForced" // forced subtitles only
+	SubtitleModeNone       = "None"       // no subtitles
+	SubtitleModeSmart      = "Smart"      // subtitles when the audio is in a foreign language
+)
+
 // QuickConnectStatus represents the status of a Quick Connect session
 type QuickConnectStatus struct {
 	Authenticated bool `json:"Authenticated"`